@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
@@ -14,26 +15,27 @@ type Service interface {
 
 // jwtService, implementasi dari Service, spesial buat JWT.
 type jwtService struct {
-	// Nggak ada field khusus di sini.
+	secretKey []byte        // Kunci rahasia buat tanda tangan digital di JWT.
+	tokenTTL  time.Duration // Lama token berlaku, 0 artinya nggak pernah kadaluarsa.
 }
 
-// SECRET_KEY, kunci rahasia buat tanda tangan digital di JWT.
-var SECRET_KEY = []byte("cob4_s3cr3tk3y")
-
-// NewService buat instance baru jwtService.
-func NewService() *jwtService {
-	return &jwtService{} // Kembalikan struct jwtService yang baru dibuat.
+// NewService buat instance baru jwtService dengan kunci rahasia dan lama berlaku token yang diberikan.
+func NewService(secretKey string, tokenTTL time.Duration) *jwtService {
+	return &jwtService{[]byte(secretKey), tokenTTL} // Kembalikan struct jwtService yang baru dibuat.
 }
 
 // GenerateToken bikin token JWT dari userID.
 func (s *jwtService) GenerateToken(userID int) (string, error) {
 	claim := jwt.MapClaims{}  // Bikin 'klaim' buat token.
 	claim["user_id"] = userID // Masukin userID ke dalam klaim.
+	if s.tokenTTL > 0 {
+		claim["exp"] = time.Now().Add(s.tokenTTL).Unix() // Token ditolak otomatis waktu validasi kalo udah lewat waktunya.
+	}
 
 	// Bikin token baru dengan metode HS256, masukin klaim tadi.
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
 
-	signedToken, err := token.SignedString(SECRET_KEY) // Tanda tangani token pake SECRET_KEY.
+	signedToken, err := token.SignedString(s.secretKey) // Tanda tangani token pake kunci rahasia.
 	if err != nil {
 		return signedToken, err // Kalo ada error, balikin errornya.
 	}
@@ -49,7 +51,7 @@ func (s *jwtService) ValidateToken(encodedtoken string) (*jwt.Token, error) {
 		if !ok {
 			return nil, errors.New("token tidak valid") // Kalo bukan, bilang tokennya nggak valid.
 		}
-		return []byte(SECRET_KEY), nil // Kalo iya, balikin SECRET_KEY buat validasi.
+		return s.secretKey, nil // Kalo iya, balikin kunci rahasia buat validasi.
 	})
 
 	if err != nil {
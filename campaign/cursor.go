@@ -0,0 +1,87 @@
+package campaign
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Pilihan urutan yang didukung buat daftar campaign.
+const (
+	SortNewest      = "newest"
+	SortMostFunded  = "most_funded"
+	SortMostBackers = "most_backers"
+	SortEndingSoon  = "ending_soon"
+)
+
+// ErrInvalidCursor dibalikin kalo cursor dari client nggak bisa dibaca.
+var ErrInvalidCursor = errors.New("cursor tidak valid")
+
+// sortOption nyimpen kolom yang dipake buat urutan dan arahnya.
+type sortOption struct {
+	column string
+	desc   bool
+}
+
+// sortOptions memetakan nilai query "sort" ke kolom di tabel campaigns.
+var sortOptions = map[string]sortOption{
+	SortNewest:      {column: "created_at", desc: true},
+	SortMostFunded:  {column: "current_amount", desc: true},
+	SortMostBackers: {column: "backer_count", desc: true},
+	SortEndingSoon:  {column: "end_date", desc: false},
+}
+
+// cursor adalah posisi terakhir yang udah dikirim ke client: nilai kolom urutan plus ID sebagai pemecah seri.
+type cursor struct {
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// encodeCursor bikin cursor dari campaign terakhir di halaman sekarang.
+func encodeCursor(campaign Campaign, sort string) string {
+	c := cursor{ID: campaign.ID}
+
+	switch sort {
+	case SortMostFunded:
+		c.Value = strconv.Itoa(campaign.CurrentAmount)
+	case SortMostBackers:
+		c.Value = strconv.Itoa(campaign.BackerCount)
+	case SortEndingSoon:
+		c.Value = campaign.EndDate.Format(time.RFC3339Nano)
+	default:
+		c.Value = campaign.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeCursor baca cursor dari client dan ubah nilainya sesuai tipe kolom urutan.
+func decodeCursor(encoded string, sort string) (interface{}, int, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.ID == 0 {
+		return nil, 0, ErrInvalidCursor
+	}
+
+	switch sort {
+	case SortMostFunded, SortMostBackers:
+		value, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return nil, 0, ErrInvalidCursor
+		}
+		return value, c.ID, nil
+	default:
+		value, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, 0, ErrInvalidCursor
+		}
+		return value, c.ID, nil
+	}
+}
@@ -1,21 +1,49 @@
 package campaign
 
-import "time"
+import (
+	"campaignku/user"
+	"time"
+)
+
+// Status campaign. Campaign mulai dari active, terus ditutup otomatis jadi successful atau failed
+// setelah lewat EndDate, tergantung dananya udah capai GoalAmount apa belum.
+const (
+	StatusActive     = "active"
+	StatusSuccessful = "successful"
+	StatusFailed     = "failed"
+)
+
+// Mode pendanaan campaign. Di mode flexible dana tetep diterima pemilik walaupun target nggak tercapai,
+// sedangkan di mode all_or_nothing semua dukungan dikembalikan kalo campaign gagal.
+const (
+	FundingFlexible     = "flexible"
+	FundingAllOrNothing = "all_or_nothing"
+)
 
 type Campaign struct {
 	ID               int
 	UserId           int
+	CategoryID       *int
 	Name             string
 	ShortDescription string
 	Description      string
-	Perks            string
 	BackerCount      int
+	FollowerCount    int
 	GoalAmount       int
 	CurrentAmount    int
 	Slug             string
+	Status           string
+	FundingMode      string
+	StartDate        time.Time
+	EndDate          time.Time
+	ClosedAt         *time.Time
 	CreatedAt        time.Time
-	UpdateAt         time.Time
-	CampaignImage    []CampaignImage
+	UpdateAt         time.Time `gorm:"column:updated_at"`
+	CampaignImages   []CampaignImage
+	User             user.User `gorm:"foreignKey:UserId"`
+	Category         *Category
+	Tags             []Tag `gorm:"many2many:campaign_tags"`
+	Rewards          []Reward
 }
 
 type CampaignImage struct {
@@ -24,5 +52,96 @@ type CampaignImage struct {
 	FileName   string
 	IsPrimary  int
 	CreatedAt  time.Time
-	UpdateAt   time.Time
+	UpdateAt   time.Time `gorm:"column:updated_at"`
+}
+
+// IsOpen ngecek campaign lagi nerima dukungan apa enggak pada waktu now.
+func (c Campaign) IsOpen(now time.Time) bool {
+	return c.Status == StatusActive && !now.Before(c.StartDate) && now.Before(c.EndDate)
+}
+
+// DaysLeft ngitung sisa hari sampai campaign ditutup, dibulatin ke atas. Campaign yang udah lewat sisa 0.
+func (c Campaign) DaysLeft(now time.Time) int {
+	if c.Status != StatusActive || !now.Before(c.EndDate) {
+		return 0
+	}
+	return int((c.EndDate.Sub(now) + 24*time.Hour - 1) / (24 * time.Hour))
+}
+
+// PercentFunded ngitung persentase dana yang udah terkumpul dibanding GoalAmount, dua angka di belakang koma.
+func (c Campaign) PercentFunded() float64 {
+	if c.GoalAmount == 0 {
+		return 0
+	}
+	return float64(c.CurrentAmount*10000/c.GoalAmount) / 100
+}
+
+// Reward adalah imbalan buat backer yang mendukung campaign minimal sebesar MinimumAmount,
+// misal "Rp100.000 dapat kaos, terbatas 50". Quantity 0 artinya jumlahnya nggak dibatasi.
+type Reward struct {
+	ID                int
+	CampaignID        int
+	Title             string
+	Description       string
+	MinimumAmount     int
+	Quantity          int
+	ReservedCount     int
+	EstimatedDelivery *time.Time
+	RequiresShipping  bool
+	CreatedAt         time.Time
+	UpdateAt          time.Time `gorm:"column:updated_at"`
+}
+
+// IsLimited ngecek jumlah reward dibatasi apa enggak.
+func (r Reward) IsLimited() bool {
+	return r.Quantity > 0
+}
+
+// Remaining ngitung sisa stok reward. Cuma bermakna kalo reward-nya terbatas.
+func (r Reward) Remaining() int {
+	if r.ReservedCount >= r.Quantity {
+		return 0
+	}
+	return r.Quantity - r.ReservedCount
+}
+
+// Category adalah kelompok campaign yang dikelola admin, misal "Pendidikan" atau "Kesehatan".
+type Category struct {
+	ID        int
+	Name      string
+	Slug      string
+	Icon      string
+	CreatedAt time.Time
+	UpdateAt  time.Time `gorm:"column:updated_at"`
+}
+
+// CategoryWithCount adalah kategori plus jumlah campaign di dalamnya.
+type CategoryWithCount struct {
+	Category
+	CampaignCount int
+}
+
+// Tag adalah label bebas yang ditempel pemilik campaign, misal "anak" atau "papua".
+type Tag struct {
+	ID        int
+	Name      string
+	Slug      string
+	CreatedAt time.Time
+}
+
+// Siapa aja yang boleh baca isi kabar terbaru campaign.
+const (
+	VisibilityPublic  = "public"  // Semua orang.
+	VisibilityBackers = "backers" // Cuma backer yang udah bayar dan pemilik campaign.
+)
+
+// CampaignUpdate adalah kabar terbaru yang diterbitin pemilik campaign buat backer-nya.
+type CampaignUpdate struct {
+	ID         int
+	CampaignID int
+	Title      string
+	Body       string
+	Visibility string
+	CreatedAt  time.Time
+	UpdateAt   time.Time `gorm:"column:updated_at"`
 }
@@ -1,15 +1,27 @@
 // Package campaign menyediakan fungsi-fungsi untuk memformat data campaign.
 package campaign
 
+import "time"
+
 // CampaignFormatter adalah struktur data yang digunakan untuk memformat data campaign sebelum dikirim sebagai respons JSON.
 type CampaignFormatter struct {
-	ID               int    `json:"id"`
-	UserID           int    `json:"user_id"`
-	Name             string `json:"name"`
-	ShortDescription string `json:"short_description"`
-	ImageURL         string `json:"image_url"`
-	GoalAmount       int    `json:"goal_amount"`
-	CurrentAmount    int    `json:"current_amount"`
+	ID               int                `json:"id"`
+	UserID           int                `json:"user_id"`
+	Name             string             `json:"name"`
+	ShortDescription string             `json:"short_description"`
+	ImageURL         string             `json:"image_url"`
+	GoalAmount       int                `json:"goal_amount"`
+	CurrentAmount    int                `json:"current_amount"`
+	PercentFunded    float64            `json:"percent_funded"`
+	FollowerCount    int                `json:"follower_count"`
+	Status           string             `json:"status"`
+	FundingMode      string             `json:"funding_mode"`
+	StartDate        time.Time          `json:"start_date"`
+	EndDate          time.Time          `json:"end_date"`
+	DaysLeft         int                `json:"days_left"`
+	Slug             string             `json:"slug"`
+	Category         *CategoryFormatter `json:"category"`
+	Tags             []string           `json:"tags"`
 }
 
 // FormatCampaign mengonversi data campaign menjadi CampaignFormatter.
@@ -21,11 +33,25 @@ func FormatCampaign(campaign Campaign) CampaignFormatter {
 		ShortDescription: campaign.ShortDescription,
 		GoalAmount:       campaign.GoalAmount,
 		CurrentAmount:    campaign.CurrentAmount,
+		PercentFunded:    campaign.PercentFunded(),
+		FollowerCount:    campaign.FollowerCount,
+		Status:           campaign.Status,
+		FundingMode:      campaign.FundingMode,
+		StartDate:        campaign.StartDate,
+		EndDate:          campaign.EndDate,
+		DaysLeft:         campaign.DaysLeft(time.Now()),
+		Slug:             campaign.Slug,
 		ImageURL:         "",
+		Tags:             formatTags(campaign.Tags),
+	}
+
+	if len(campaign.CampaignImages) > 0 {
+		formatter.ImageURL = campaign.CampaignImages[0].FileName
 	}
 
-	if len(campaign.CampaignImage) > 0 {
-		formatter.ImageURL = campaign.CampaignImage[0].FileName
+	if campaign.Category != nil {
+		category := FormatCategory(*campaign.Category)
+		formatter.Category = &category
 	}
 
 	return formatter
@@ -42,3 +68,243 @@ func FormatCampaigns(campaigns []Campaign) []CampaignFormatter {
 
 	return campaignsFormatter
 }
+
+// CampaignDetailFormatter adalah struktur data buat detail satu campaign.
+type CampaignDetailFormatter struct {
+	ID               int                      `json:"id"`
+	Name             string                   `json:"name"`
+	ShortDescription string                   `json:"short_description"`
+	Description      string                   `json:"description"`
+	ImageURL         string                   `json:"image_url"`
+	GoalAmount       int                      `json:"goal_amount"`
+	CurrentAmount    int                      `json:"current_amount"`
+	PercentFunded    float64                  `json:"percent_funded"`
+	BackerCount      int                      `json:"backer_count"`
+	FollowerCount    int                      `json:"follower_count"`
+	Status           string                   `json:"status"`
+	FundingMode      string                   `json:"funding_mode"`
+	StartDate        time.Time                `json:"start_date"`
+	EndDate          time.Time                `json:"end_date"`
+	DaysLeft         int                      `json:"days_left"`
+	UserID           int                      `json:"user_id"`
+	Slug             string                   `json:"slug"`
+	Rewards          []RewardFormatter        `json:"rewards"`
+	User             CampaignUserFormatter    `json:"user"`
+	Images           []CampaignImageFormatter `json:"images"`
+	Category         *CategoryFormatter       `json:"category"`
+	Tags             []string                 `json:"tags"`
+}
+
+// CampaignUserFormatter adalah data pemilik campaign yang boleh ditampilin ke publik.
+type CampaignUserFormatter struct {
+	Name     string `json:"name"`
+	ImageURL string `json:"image_url"`
+}
+
+// CampaignImageFormatter adalah data satu gambar campaign.
+type CampaignImageFormatter struct {
+	ImageURL  string `json:"image_url"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// FormatCampaignDetail mengonversi data campaign menjadi CampaignDetailFormatter.
+func FormatCampaignDetail(campaign Campaign) CampaignDetailFormatter {
+	formatter := CampaignDetailFormatter{
+		ID:               campaign.ID,
+		Name:             campaign.Name,
+		ShortDescription: campaign.ShortDescription,
+		Description:      campaign.Description,
+		GoalAmount:       campaign.GoalAmount,
+		CurrentAmount:    campaign.CurrentAmount,
+		PercentFunded:    campaign.PercentFunded(),
+		BackerCount:      campaign.BackerCount,
+		FollowerCount:    campaign.FollowerCount,
+		Status:           campaign.Status,
+		FundingMode:      campaign.FundingMode,
+		StartDate:        campaign.StartDate,
+		EndDate:          campaign.EndDate,
+		DaysLeft:         campaign.DaysLeft(time.Now()),
+		UserID:           campaign.UserId,
+		Slug:             campaign.Slug,
+		Rewards:          FormatRewards(campaign.Rewards),
+		Images:           []CampaignImageFormatter{},
+		Tags:             formatTags(campaign.Tags),
+	}
+
+	formatter.User = CampaignUserFormatter{
+		Name:     campaign.User.Name,
+		ImageURL: campaign.User.AvatarFileName,
+	}
+
+	for _, image := range campaign.CampaignImages {
+		imageFormatter := CampaignImageFormatter{
+			ImageURL:  image.FileName,
+			IsPrimary: image.IsPrimary == 1,
+		}
+		if imageFormatter.IsPrimary {
+			formatter.ImageURL = image.FileName
+		}
+		formatter.Images = append(formatter.Images, imageFormatter)
+	}
+
+	if campaign.Category != nil {
+		category := FormatCategory(*campaign.Category)
+		formatter.Category = &category
+	}
+
+	return formatter
+}
+
+// RewardFormatter adalah struktur data buat satu reward campaign.
+// Quantity dan Remaining bernilai null kalo jumlah reward-nya nggak dibatasi.
+type RewardFormatter struct {
+	ID                int        `json:"id"`
+	Title             string     `json:"title"`
+	Description       string     `json:"description"`
+	MinimumAmount     int        `json:"minimum_amount"`
+	Quantity          *int       `json:"quantity"`
+	Remaining         *int       `json:"remaining"`
+	EstimatedDelivery *time.Time `json:"estimated_delivery"`
+	RequiresShipping  bool       `json:"requires_shipping"`
+}
+
+// FormatReward mengonversi data reward menjadi RewardFormatter.
+func FormatReward(reward Reward) RewardFormatter {
+	formatter := RewardFormatter{
+		ID:                reward.ID,
+		Title:             reward.Title,
+		Description:       reward.Description,
+		MinimumAmount:     reward.MinimumAmount,
+		EstimatedDelivery: reward.EstimatedDelivery,
+		RequiresShipping:  reward.RequiresShipping,
+	}
+
+	if reward.IsLimited() {
+		quantity := reward.Quantity
+		remaining := reward.Remaining()
+		formatter.Quantity = &quantity
+		formatter.Remaining = &remaining
+	}
+
+	return formatter
+}
+
+// FormatRewards mengonversi daftar reward menjadi daftar RewardFormatter.
+func FormatRewards(rewards []Reward) []RewardFormatter {
+	rewardsFormatter := []RewardFormatter{}
+
+	for _, reward := range rewards {
+		rewardsFormatter = append(rewardsFormatter, FormatReward(reward))
+	}
+
+	return rewardsFormatter
+}
+
+// CategoryFormatter adalah struktur data buat satu kategori.
+type CategoryFormatter struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	Icon          string `json:"icon"`
+	CampaignCount *int   `json:"campaign_count,omitempty"`
+}
+
+// FormatCategory mengonversi data kategori menjadi CategoryFormatter.
+func FormatCategory(category Category) CategoryFormatter {
+	return CategoryFormatter{
+		ID:   category.ID,
+		Name: category.Name,
+		Slug: category.Slug,
+		Icon: category.Icon,
+	}
+}
+
+// FormatCategories mengonversi daftar kategori plus jumlah campaign-nya menjadi daftar CategoryFormatter.
+func FormatCategories(categories []CategoryWithCount) []CategoryFormatter {
+	categoriesFormatter := []CategoryFormatter{}
+
+	for _, category := range categories {
+		categoryFormatter := FormatCategory(category.Category)
+		campaignCount := category.CampaignCount
+		categoryFormatter.CampaignCount = &campaignCount
+		categoriesFormatter = append(categoriesFormatter, categoryFormatter)
+	}
+
+	return categoriesFormatter
+}
+
+// formatTags ngambil nama-nama tag aja buat ditampilin.
+func formatTags(tags []Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// SearchResultFormatter adalah struktur data buat hasil pencarian campaign: data campaign plus skor dan potongan teksnya.
+type SearchResultFormatter struct {
+	CampaignFormatter
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+// FormatSearchResults mengonversi hasil pencarian menjadi daftar SearchResultFormatter.
+func FormatSearchResults(results []SearchResult) []SearchResultFormatter {
+	resultsFormatter := []SearchResultFormatter{}
+
+	for _, result := range results {
+		resultFormatter := SearchResultFormatter{
+			CampaignFormatter: FormatCampaign(result.Campaign),
+			Score:             result.Score,
+			Highlights:        result.Highlights,
+		}
+		resultsFormatter = append(resultsFormatter, resultFormatter)
+	}
+
+	return resultsFormatter
+}
+
+// CampaignUpdateFormatter adalah struktur data buat satu kabar terbaru campaign.
+// Kalo kabarnya khusus backer dan yang baca bukan backer, isinya dikosongin dan Locked bernilai true.
+type CampaignUpdateFormatter struct {
+	ID         int       `json:"id"`
+	CampaignID int       `json:"campaign_id"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	Visibility string    `json:"visibility"`
+	Locked     bool      `json:"locked"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// FormatCampaignUpdate mengonversi data kabar terbaru menjadi CampaignUpdateFormatter.
+func FormatCampaignUpdate(update CampaignUpdate, canViewBackersOnly bool) CampaignUpdateFormatter {
+	formatter := CampaignUpdateFormatter{
+		ID:         update.ID,
+		CampaignID: update.CampaignID,
+		Title:      update.Title,
+		Body:       update.Body,
+		Visibility: update.Visibility,
+		CreatedAt:  update.CreatedAt,
+		UpdatedAt:  update.UpdateAt,
+	}
+
+	if update.Visibility == VisibilityBackers && !canViewBackersOnly {
+		formatter.Body = ""
+		formatter.Locked = true
+	}
+
+	return formatter
+}
+
+// FormatCampaignUpdates mengonversi daftar kabar terbaru menjadi daftar CampaignUpdateFormatter.
+func FormatCampaignUpdates(updates []CampaignUpdate, canViewBackersOnly bool) []CampaignUpdateFormatter {
+	updatesFormatter := []CampaignUpdateFormatter{}
+
+	for _, update := range updates {
+		updatesFormatter = append(updatesFormatter, FormatCampaignUpdate(update, canViewBackersOnly))
+	}
+
+	return updatesFormatter
+}
@@ -1 +1,95 @@
 package campaign
+
+import (
+	"campaignku/user"
+	"time"
+)
+
+// GetCampaignsInput adalah struktur data buat nampung query string saat minta daftar campaign.
+// Isinya filter, urutan, dan paginasi (bisa pake nomor halaman atau cursor).
+type GetCampaignsInput struct {
+	UserID   int    `form:"user_id"`                                                                    // Filter berdasarkan pemilik campaign.
+	MinGoal  int    `form:"min_goal" binding:"omitempty,min=0"`                                         // Target dana minimal.
+	MaxGoal  int    `form:"max_goal" binding:"omitempty,min=0,gtefield=MinGoal"`                        // Target dana maksimal, nggak boleh di bawah min_goal.
+	Funded   *bool  `form:"funded"`                                                                     // true = udah capai target, false = belum.
+	Status   string `form:"status" binding:"omitempty,oneof=active successful failed"`                  // Filter berdasarkan status campaign.
+	Category string `form:"category"`                                                                   // Filter berdasarkan slug kategori.
+	Tag      string `form:"tag"`                                                                        // Filter berdasarkan slug tag.
+	Sort     string `form:"sort" binding:"omitempty,oneof=newest most_funded most_backers ending_soon"` // Urutan hasil.
+	Page     int    `form:"page" binding:"omitempty,min=1"`                                             // Nomor halaman, mulai dari 1.
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`                                    // Jumlah data per halaman.
+	Cursor   string `form:"cursor"`                                                                     // Cursor dari respons sebelumnya.
+}
+
+// GetCampaignDetailInput adalah struktur data buat nampung ID campaign dari URI.
+type GetCampaignDetailInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// CreateCampaignInput adalah struktur data yang digunakan sebagai input saat membuat atau mengubah campaign.
+type CreateCampaignInput struct {
+	Name             string    `json:"name" binding:"required"`
+	ShortDescription string    `json:"short_description" binding:"required"`
+	Description      string    `json:"description" binding:"required"`
+	GoalAmount       int       `json:"goal_amount" binding:"required,min=1"`
+	CategoryID       *int      `json:"category_id"`
+	Tags             []string  `json:"tags" binding:"omitempty,max=10,dive,required,max=30"`
+	FundingMode      string    `json:"funding_mode" binding:"omitempty,oneof=flexible all_or_nothing"`
+	StartDate        time.Time `json:"start_date" binding:"required"`
+	EndDate          time.Time `json:"end_date" binding:"required,gtfield=StartDate"`
+	User             user.User // Diisi dari user yang lagi login, bukan dari JSON.
+}
+
+// SearchCampaignsInput adalah struktur data buat nampung query string saat mencari campaign.
+type SearchCampaignsInput struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// GetCategoryInput adalah struktur data buat nampung ID kategori dari URI.
+type GetCategoryInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// CategoryInput adalah struktur data yang digunakan sebagai input saat admin membuat atau mengubah kategori.
+type CategoryInput struct {
+	Name string `json:"name" binding:"required,max=50"`
+	Icon string `json:"icon" binding:"omitempty,max=255"`
+}
+
+// GetRewardInput adalah struktur data buat nampung ID campaign dan ID reward dari URI.
+type GetRewardInput struct {
+	CampaignID int `uri:"id" binding:"required"`
+	ID         int `uri:"reward_id" binding:"required"`
+}
+
+// RewardInput adalah struktur data yang digunakan sebagai input saat pemilik campaign membuat atau mengubah reward.
+type RewardInput struct {
+	Title             string     `json:"title" binding:"required,max=100"`
+	Description       string     `json:"description"`
+	MinimumAmount     int        `json:"minimum_amount" binding:"required,min=1"`
+	Quantity          int        `json:"quantity" binding:"min=0"` // 0 artinya nggak terbatas.
+	EstimatedDelivery *time.Time `json:"estimated_delivery"`
+	RequiresShipping  bool       `json:"requires_shipping"`
+	User              user.User  // Diisi dari user yang lagi login, bukan dari JSON.
+}
+
+// GetCampaignUpdateInput adalah struktur data buat nampung ID campaign dan ID kabar terbaru dari URI.
+type GetCampaignUpdateInput struct {
+	CampaignID int `uri:"id" binding:"required"`
+	ID         int `uri:"update_id" binding:"required"`
+}
+
+// CampaignUpdateInput adalah struktur data yang digunakan sebagai input saat pemilik campaign nerbitin atau ngubah kabar terbaru.
+type CampaignUpdateInput struct {
+	Title      string    `json:"title" binding:"required,max=150"`
+	Body       string    `json:"body" binding:"required"`
+	Visibility string    `json:"visibility" binding:"omitempty,oneof=public backers"` // Kosong artinya public.
+	User       user.User // Diisi dari user yang lagi login, bukan dari JSON.
+}
+
+// CampaignImageInput adalah struktur data yang digunakan sebagai input saat pemilik campaign ngunggah gambar.
+type CampaignImageInput struct {
+	IsPrimary bool      `form:"is_primary"` // Jadiin gambar utama campaign.
+	User      user.User // Diisi dari user yang lagi login, bukan dari form.
+}
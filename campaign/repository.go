@@ -1,11 +1,51 @@
 package campaign
 
-import "gorm.io/gorm"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 // Repository adalah interface untuk fungsi-fungsi database yang berkaitan dengan Campaign.
 type Repository interface {
-	FindAll() ([]Campaign, error)                // Fungsi untuk dapetin semua campaign.
-	FindByUserID(userID int) ([]Campaign, error) // Fungsi untuk dapetin campaign berdasarkan ID user.
+	FindAll(ctx context.Context, input GetCampaignsInput) ([]Campaign, int64, error)       // Fungsi untuk dapetin campaign sesuai filter, urutan, dan paginasi.
+	FindByUserID(ctx context.Context, userID int) ([]Campaign, error)                      // Fungsi untuk dapetin campaign berdasarkan ID user.
+	FindByID(ctx context.Context, ID int) (Campaign, error)                                // Fungsi untuk dapetin satu campaign berdasarkan ID.
+	FindByIDs(ctx context.Context, IDs []int) ([]Campaign, error)                          // Fungsi untuk dapetin beberapa campaign sekaligus, urutannya ngikutin IDs.
+	FindBySlug(ctx context.Context, slug string) (Campaign, error)                         // Fungsi untuk dapetin satu campaign berdasarkan slug.
+	FindInBatches(ctx context.Context, size int, fn func([]Campaign) error) error          // Fungsi untuk nyusurin semua campaign sedikit demi sedikit.
+	Save(ctx context.Context, campaign Campaign) (Campaign, error)                         // Fungsi untuk nyimpen campaign baru.
+	Update(ctx context.Context, campaign Campaign) (Campaign, error)                       // Fungsi untuk nyimpen perubahan campaign.
+	ReplaceTags(ctx context.Context, campaign Campaign, tags []Tag) error                  // Fungsi untuk ganti semua tag di campaign.
+	FindOrCreateTags(ctx context.Context, names []string) ([]Tag, error)                   // Fungsi untuk dapetin tag berdasarkan nama, dibikin kalo belum ada.
+	FindCategories(ctx context.Context) ([]CategoryWithCount, error)                       // Fungsi untuk dapetin semua kategori plus jumlah campaign-nya.
+	FindCategoryByID(ctx context.Context, ID int) (Category, error)                        // Fungsi untuk dapetin kategori berdasarkan ID.
+	FindCategoryBySlug(ctx context.Context, slug string) (Category, error)                 // Fungsi untuk dapetin kategori berdasarkan slug.
+	SaveCategory(ctx context.Context, category Category) (Category, error)                 // Fungsi untuk nyimpen kategori baru.
+	UpdateCategory(ctx context.Context, category Category) (Category, error)               // Fungsi untuk nyimpen perubahan kategori.
+	DeleteCategory(ctx context.Context, category Category) error                           // Fungsi untuk ngapus kategori, campaign di dalamnya jadi tanpa kategori.
+	FindExpired(ctx context.Context, now time.Time) ([]Campaign, error)                    // Fungsi untuk dapetin campaign aktif yang udah lewat tanggal akhirnya.
+	Close(ctx context.Context, campaign Campaign) (bool, error)                            // Fungsi untuk nutup campaign aktif, balikin false kalo udah ditutup duluan.
+	AddFunds(ctx context.Context, campaignID int, amount int, backers int) error           // Fungsi untuk nambah (atau ngurangin) dana dan jumlah backer secara atomik.
+	FindRewardByID(ctx context.Context, ID int) (Reward, error)                            // Fungsi untuk dapetin reward berdasarkan ID.
+	SaveReward(ctx context.Context, reward Reward) (Reward, error)                         // Fungsi untuk nyimpen reward baru.
+	UpdateReward(ctx context.Context, reward Reward) (Reward, error)                       // Fungsi untuk nyimpen perubahan reward.
+	DeleteReward(ctx context.Context, reward Reward) error                                 // Fungsi untuk ngapus reward.
+	ReserveReward(ctx context.Context, rewardID int) (bool, error)                         // Fungsi untuk ngambil satu stok reward secara atomik, false kalo stoknya habis.
+	ReleaseReward(ctx context.Context, rewardID int) error                                 // Fungsi untuk ngembaliin satu stok reward.
+	FindUpdatesByCampaignID(ctx context.Context, campaignID int) ([]CampaignUpdate, error) // Fungsi untuk dapetin kabar terbaru campaign, yang paling baru duluan.
+	FindUpdateByID(ctx context.Context, ID int) (CampaignUpdate, error)                    // Fungsi untuk dapetin satu kabar terbaru berdasarkan ID.
+	SaveUpdate(ctx context.Context, update CampaignUpdate) (CampaignUpdate, error)         // Fungsi untuk nyimpen kabar terbaru baru.
+	UpdateUpdate(ctx context.Context, update CampaignUpdate) (CampaignUpdate, error)       // Fungsi untuk nyimpen perubahan kabar terbaru.
+	DeleteUpdate(ctx context.Context, update CampaignUpdate) error                         // Fungsi untuk ngapus kabar terbaru.
+	FindBackerIDs(ctx context.Context, campaignID int) ([]int, error)                      // Fungsi untuk dapetin ID semua backer yang udah bayar di sebuah campaign.
+	IsBacker(ctx context.Context, campaignID int, userID int) (bool, error)                // Fungsi untuk ngecek user udah pernah dukung (dan bayar) campaign apa belum.
+	FindUpdatesByIDs(ctx context.Context, IDs []int) ([]CampaignUpdate, error)             // Fungsi untuk dapetin beberapa kabar terbaru sekaligus.
+	SaveImage(ctx context.Context, image CampaignImage) (CampaignImage, error)             // Fungsi untuk nyimpen gambar campaign baru.
+	RecomputeTotals(ctx context.Context, campaignID int) (int64, error)                    // Fungsi untuk ngitung ulang dana dan jumlah backer dari transaksi yang lunas.
 }
 
 // repository adalah implementasi dari Repository, pakai GORM.
@@ -18,26 +58,535 @@ func NewRepository(db *gorm.DB) *repository {
 	return &repository{db} // Balikin struct repository baru dengan DB yang sudah di-set.
 }
 
-// FindAll adalah method dari repository untuk dapetin semua campaign.
-func (r *repository) FindAll() ([]Campaign, error) {
+// FindAll adalah method dari repository untuk dapetin campaign sesuai filter, urutan, dan paginasi.
+// Selain daftar campaign, method ini juga balikin total campaign yang cocok sama filter.
+// Input diasumsikan udah dinormalisasi sama service (Sort dan Limit udah keisi).
+func (r *repository) FindAll(ctx context.Context, input GetCampaignsInput) ([]Campaign, int64, error) {
 	var campaigns []Campaign // Siapin slice untuk tampung data campaign.
+	var total int64
 
-	// Query ke database, preload CampaignImages dengan kondisi is_primary = 1.
-	err := r.db.Preload("CampaignImages", "campaign_images.is_primary = 1").Find(&campaigns).Error
+	// Hitung dulu total campaign yang cocok sama filter, tanpa paginasi.
+	err := r.filter(ctx, input).Model(&Campaign{}).Count(&total).Error
 	if err != nil {
-		return campaigns, err // Kalo ada error, balikin errornya.
+		return campaigns, total, err
+	}
+
+	option := sortOptions[input.Sort]
+	direction, comparator := "ASC", ">"
+	if option.desc {
+		direction, comparator = "DESC", "<"
+	}
+
+	query := r.filter(ctx, input).Order(fmt.Sprintf("%s %s, id %s", option.column, direction, direction))
+
+	// Kalo ada cursor, lanjut dari posisi terakhir. Kalo nggak, pake nomor halaman.
+	if input.Cursor != "" {
+		value, id, err := decodeCursor(input.Cursor, input.Sort)
+		if err != nil {
+			return campaigns, total, err
+		}
+		condition := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", option.column, comparator)
+		query = query.Where(condition, value, value, id)
+	} else if input.Page > 1 {
+		query = query.Offset((input.Page - 1) * input.Limit)
+	}
+
+	// Ambil satu data lebih, buat tau masih ada halaman berikutnya apa enggak.
+	err = query.Limit(input.Limit+1).Preload("CampaignImages", "campaign_images.is_primary = 1").Find(&campaigns).Error
+	if err != nil {
+		return campaigns, total, err // Kalo ada error, balikin errornya.
+	}
+	return campaigns, total, nil // Kalo sukses, balikin list campaign.
+}
+
+// filter nyusun query dasar dari filter yang dikirim client.
+func (r *repository) filter(ctx context.Context, input GetCampaignsInput) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&Campaign{})
+
+	if input.UserID != 0 {
+		query = query.Where("user_id = ?", input.UserID)
+	}
+	if input.MinGoal > 0 {
+		query = query.Where("goal_amount >= ?", input.MinGoal)
+	}
+	if input.MaxGoal > 0 {
+		query = query.Where("goal_amount <= ?", input.MaxGoal)
+	}
+	if input.Funded != nil {
+		if *input.Funded {
+			query = query.Where("current_amount >= goal_amount")
+		} else {
+			query = query.Where("current_amount < goal_amount")
+		}
 	}
-	return campaigns, nil // Kalo sukses, balikin list campaign.
+	if input.Status != "" {
+		query = query.Where("status = ?", input.Status)
+	}
+	// "Segera berakhir" cuma masuk akal buat campaign yang masih jalan, yang udah ditutup atau lewat tanggalnya nggak ikut.
+	if input.Sort == SortEndingSoon {
+		query = query.Where("status = ? AND end_date > ?", StatusActive, time.Now())
+	}
+	if input.Category != "" {
+		query = query.Where("category_id IN (?)", r.db.WithContext(ctx).Model(&Category{}).Select("id").Where("slug = ?", input.Category))
+	}
+	if input.Tag != "" {
+		tagged := r.db.WithContext(ctx).Table("campaign_tags").
+			Select("campaign_tags.campaign_id").
+			Joins("JOIN tags ON tags.id = campaign_tags.tag_id").
+			Where("tags.slug = ?", input.Tag)
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	return query
 }
 
 // FindByUserID adalah method dari repository untuk dapetin campaign berdasarkan ID user.
-func (r *repository) FindByUserID(userID int) ([]Campaign, error) {
+func (r *repository) FindByUserID(ctx context.Context, userID int) ([]Campaign, error) {
 	var campaigns []Campaign // Siapin slice untuk tampung data campaign.
 
 	// Query ke database, cari berdasarkan user_id dan preload CampaignImages.
-	err := r.db.Where("user_id = ?", userID).Preload("CampaignImages", "campaign_images.is_primary = 1").Find(&campaigns).Error
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Preload("CampaignImages", "campaign_images.is_primary = 1").Find(&campaigns).Error
 	if err != nil {
 		return campaigns, err // Kalo ada error, balikin errornya.
 	}
 	return campaigns, nil // Kalo sukses, balikin list campaign sesuai user ID.
 }
+
+// FindByID adalah method dari repository untuk dapetin satu campaign berdasarkan ID,
+// lengkap sama semua gambar, pemilik, kategori, dan tag-nya.
+func (r *repository) FindByID(ctx context.Context, ID int) (Campaign, error) {
+	var campaign Campaign
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).
+		Preload("CampaignImages").
+		Preload("User").
+		Preload("Category").
+		Preload("Tags").
+		Preload("Rewards", func(db *gorm.DB) *gorm.DB {
+			return db.Order("minimum_amount ASC")
+		}).
+		Find(&campaign).Error
+	if err != nil {
+		return campaign, err
+	}
+	return campaign, nil
+}
+
+// FindByIDs adalah method dari repository untuk dapetin beberapa campaign sekaligus.
+// Urutan hasilnya disamain sama urutan IDs, ID yang nggak ketemu dilewatin aja.
+func (r *repository) FindByIDs(ctx context.Context, IDs []int) ([]Campaign, error) {
+	var found []Campaign
+
+	if len(IDs) == 0 {
+		return []Campaign{}, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("id IN ?", IDs).
+		Preload("CampaignImages", "campaign_images.is_primary = 1").
+		Preload("Category").
+		Preload("Tags").
+		Find(&found).Error
+	if err != nil {
+		return found, err
+	}
+
+	byID := make(map[int]Campaign, len(found))
+	for _, campaign := range found {
+		byID[campaign.ID] = campaign
+	}
+
+	campaigns := make([]Campaign, 0, len(found))
+	for _, ID := range IDs {
+		if campaign, ok := byID[ID]; ok {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+	return campaigns, nil
+}
+
+// FindBySlug adalah method dari repository untuk dapetin satu campaign berdasarkan slug.
+func (r *repository) FindBySlug(ctx context.Context, slug string) (Campaign, error) {
+	var campaign Campaign
+
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).Find(&campaign).Error
+	if err != nil {
+		return campaign, err
+	}
+	return campaign, nil
+}
+
+// FindInBatches adalah method dari repository untuk nyusurin semua campaign per kelompok sebanyak size.
+func (r *repository) FindInBatches(ctx context.Context, size int, fn func([]Campaign) error) error {
+	var campaigns []Campaign
+
+	return r.db.WithContext(ctx).FindInBatches(&campaigns, size, func(tx *gorm.DB, batch int) error {
+		return fn(campaigns)
+	}).Error
+}
+
+// Save adalah method dari repository untuk nyimpen campaign baru.
+func (r *repository) Save(ctx context.Context, campaign Campaign) (Campaign, error) {
+	now := time.Now()
+	campaign.CreatedAt = now
+	campaign.UpdateAt = now
+
+	// Relasi kayak User dan Tags diurus terpisah, jangan ikut disimpen di sini.
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Create(&campaign).Error
+	if err != nil {
+		return campaign, err
+	}
+	return campaign, nil
+}
+
+// Update adalah method dari repository untuk nyimpen perubahan campaign.
+func (r *repository) Update(ctx context.Context, campaign Campaign) (Campaign, error) {
+	campaign.UpdateAt = time.Now()
+
+	// Kolom hitungan cuma boleh diubah secara atomik (AddFunds dan follow), jadi nggak ikut ditimpa di sini.
+	err := r.db.WithContext(ctx).Omit(clause.Associations, "current_amount", "backer_count", "follower_count").Save(&campaign).Error
+	if err != nil {
+		return campaign, err
+	}
+	return campaign, nil
+}
+
+// ReplaceTags adalah method dari repository untuk ganti semua tag di campaign dengan tags.
+func (r *repository) ReplaceTags(ctx context.Context, campaign Campaign, tags []Tag) error {
+	return r.db.WithContext(ctx).Model(&campaign).Association("Tags").Replace(tags)
+}
+
+// FindOrCreateTags adalah method dari repository untuk dapetin tag berdasarkan nama.
+// Tag dicocokin pake slug-nya, jadi "Anak Yatim" dan "anak-yatim" dianggap tag yang sama.
+func (r *repository) FindOrCreateTags(ctx context.Context, names []string) ([]Tag, error) {
+	tags := []Tag{}
+
+	for _, name := range names {
+		tag := Tag{Name: name, Slug: slugify(name)}
+		if tag.Slug == "" {
+			continue
+		}
+
+		err := r.db.WithContext(ctx).Where(Tag{Slug: tag.Slug}).FirstOrCreate(&tag).Error
+		if err != nil {
+			return tags, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// FindCategories adalah method dari repository untuk dapetin semua kategori plus jumlah campaign-nya.
+func (r *repository) FindCategories(ctx context.Context) ([]CategoryWithCount, error) {
+	var categories []CategoryWithCount
+
+	err := r.db.WithContext(ctx).Model(&Category{}).
+		Select("categories.*, COUNT(campaigns.id) AS campaign_count").
+		Joins("LEFT JOIN campaigns ON campaigns.category_id = categories.id").
+		Group("categories.id").
+		Order("categories.name").
+		Scan(&categories).Error
+	if err != nil {
+		return categories, err
+	}
+	return categories, nil
+}
+
+// FindCategoryByID adalah method dari repository untuk dapetin kategori berdasarkan ID.
+func (r *repository) FindCategoryByID(ctx context.Context, ID int) (Category, error) {
+	var category Category
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Find(&category).Error
+	if err != nil {
+		return category, err
+	}
+	return category, nil
+}
+
+// FindCategoryBySlug adalah method dari repository untuk dapetin kategori berdasarkan slug.
+func (r *repository) FindCategoryBySlug(ctx context.Context, slug string) (Category, error) {
+	var category Category
+
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).Find(&category).Error
+	if err != nil {
+		return category, err
+	}
+	return category, nil
+}
+
+// SaveCategory adalah method dari repository untuk nyimpen kategori baru.
+func (r *repository) SaveCategory(ctx context.Context, category Category) (Category, error) {
+	now := time.Now()
+	category.CreatedAt = now
+	category.UpdateAt = now
+
+	err := r.db.WithContext(ctx).Create(&category).Error
+	if err != nil {
+		return category, err
+	}
+	return category, nil
+}
+
+// UpdateCategory adalah method dari repository untuk nyimpen perubahan kategori.
+func (r *repository) UpdateCategory(ctx context.Context, category Category) (Category, error) {
+	category.UpdateAt = time.Now()
+
+	err := r.db.WithContext(ctx).Save(&category).Error
+	if err != nil {
+		return category, err
+	}
+	return category, nil
+}
+
+// DeleteCategory adalah method dari repository untuk ngapus kategori.
+// Campaign yang ada di kategori itu dilepas dulu biar nggak nunjuk ke kategori yang udah hilang.
+func (r *repository) DeleteCategory(ctx context.Context, category Category) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Campaign{}).Where("category_id = ?", category.ID).Update("category_id", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&category).Error
+	})
+}
+
+// FindExpired adalah method dari repository untuk dapetin campaign yang masih aktif tapi udah lewat tanggal akhirnya.
+func (r *repository) FindExpired(ctx context.Context, now time.Time) ([]Campaign, error) {
+	var campaigns []Campaign
+
+	err := r.db.WithContext(ctx).Where("status = ? AND end_date <= ?", StatusActive, now).Find(&campaigns).Error
+	if err != nil {
+		return campaigns, err
+	}
+	return campaigns, nil
+}
+
+// Close adalah method dari repository untuk nyimpen status akhir campaign.
+// Cuma campaign yang masih active yang diubah, jadi aman kalo dua proses nutup campaign yang sama barengan.
+func (r *repository) Close(ctx context.Context, campaign Campaign) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Campaign{}).
+		Where("id = ? AND status = ?", campaign.ID, StatusActive).
+		Updates(map[string]interface{}{
+			"status":     campaign.Status,
+			"closed_at":  campaign.ClosedAt,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// AddFunds adalah method dari repository untuk nambah dana dan jumlah backer campaign langsung di database,
+// biar transaksi yang lunas barengan nggak saling nimpa. Nilai negatif dipake buat refund.
+func (r *repository) AddFunds(ctx context.Context, campaignID int, amount int, backers int) error {
+	return r.db.WithContext(ctx).Model(&Campaign{}).
+		Where("id = ?", campaignID).
+		Updates(map[string]interface{}{
+			"current_amount": gorm.Expr("current_amount + ?", amount),
+			"backer_count":   gorm.Expr("backer_count + ?", backers),
+			"updated_at":     time.Now(),
+		}).Error
+}
+
+// FindRewardByID adalah method dari repository untuk dapetin reward berdasarkan ID.
+func (r *repository) FindRewardByID(ctx context.Context, ID int) (Reward, error) {
+	var reward Reward
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Find(&reward).Error
+	if err != nil {
+		return reward, err
+	}
+	return reward, nil
+}
+
+// SaveReward adalah method dari repository untuk nyimpen reward baru.
+func (r *repository) SaveReward(ctx context.Context, reward Reward) (Reward, error) {
+	now := time.Now()
+	reward.CreatedAt = now
+	reward.UpdateAt = now
+
+	err := r.db.WithContext(ctx).Create(&reward).Error
+	if err != nil {
+		return reward, err
+	}
+	return reward, nil
+}
+
+// UpdateReward adalah method dari repository untuk nyimpen perubahan reward.
+// Kolom reserved_count sengaja nggak ikut disimpen, karena cuma boleh diubah lewat ReserveReward dan ReleaseReward.
+func (r *repository) UpdateReward(ctx context.Context, reward Reward) (Reward, error) {
+	reward.UpdateAt = time.Now()
+
+	err := r.db.WithContext(ctx).Omit("reserved_count").Save(&reward).Error
+	if err != nil {
+		return reward, err
+	}
+	return reward, nil
+}
+
+// DeleteReward adalah method dari repository untuk ngapus reward.
+func (r *repository) DeleteReward(ctx context.Context, reward Reward) error {
+	return r.db.WithContext(ctx).Delete(&reward).Error
+}
+
+// ReserveReward adalah method dari repository untuk ngambil satu stok reward.
+// Pengecekan stok dan penambahan reserved_count dilakuin dalam satu query, jadi dua backer nggak bisa rebutan stok terakhir.
+func (r *repository) ReserveReward(ctx context.Context, rewardID int) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Reward{}).
+		Where("id = ? AND (quantity = 0 OR reserved_count < quantity)", rewardID).
+		Update("reserved_count", gorm.Expr("reserved_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseReward adalah method dari repository untuk ngembaliin satu stok reward, misal karena pembayarannya kadaluarsa.
+func (r *repository) ReleaseReward(ctx context.Context, rewardID int) error {
+	return r.db.WithContext(ctx).Model(&Reward{}).
+		Where("id = ? AND reserved_count > 0", rewardID).
+		Update("reserved_count", gorm.Expr("reserved_count - 1")).Error
+}
+
+// Status transaksi yang udah dibayar. Ditulis ulang di sini karena package transaction yang bergantung ke package campaign, bukan sebaliknya.
+const paidTransactionStatus = "paid"
+
+// FindUpdatesByCampaignID adalah method dari repository untuk dapetin kabar terbaru sebuah campaign, yang paling baru duluan.
+func (r *repository) FindUpdatesByCampaignID(ctx context.Context, campaignID int) ([]CampaignUpdate, error) {
+	var updates []CampaignUpdate
+
+	err := r.db.WithContext(ctx).Where("campaign_id = ?", campaignID).Order("created_at DESC, id DESC").Find(&updates).Error
+	if err != nil {
+		return updates, err
+	}
+	return updates, nil
+}
+
+// FindUpdateByID adalah method dari repository untuk dapetin satu kabar terbaru berdasarkan ID.
+func (r *repository) FindUpdateByID(ctx context.Context, ID int) (CampaignUpdate, error) {
+	var update CampaignUpdate
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Find(&update).Error
+	if err != nil {
+		return update, err
+	}
+	return update, nil
+}
+
+// FindUpdatesByIDs adalah method dari repository untuk dapetin beberapa kabar terbaru sekaligus.
+func (r *repository) FindUpdatesByIDs(ctx context.Context, IDs []int) ([]CampaignUpdate, error) {
+	var updates []CampaignUpdate
+
+	if len(IDs) == 0 {
+		return updates, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("id IN ?", IDs).Find(&updates).Error
+	if err != nil {
+		return updates, err
+	}
+	return updates, nil
+}
+
+// SaveUpdate adalah method dari repository untuk nyimpen kabar terbaru baru.
+func (r *repository) SaveUpdate(ctx context.Context, update CampaignUpdate) (CampaignUpdate, error) {
+	now := time.Now()
+	update.CreatedAt = now
+	update.UpdateAt = now
+
+	err := r.db.WithContext(ctx).Create(&update).Error
+	if err != nil {
+		return update, err
+	}
+	return update, nil
+}
+
+// UpdateUpdate adalah method dari repository untuk nyimpen perubahan kabar terbaru.
+func (r *repository) UpdateUpdate(ctx context.Context, update CampaignUpdate) (CampaignUpdate, error) {
+	update.UpdateAt = time.Now()
+
+	err := r.db.WithContext(ctx).Save(&update).Error
+	if err != nil {
+		return update, err
+	}
+	return update, nil
+}
+
+// DeleteUpdate adalah method dari repository untuk ngapus kabar terbaru.
+func (r *repository) DeleteUpdate(ctx context.Context, update CampaignUpdate) error {
+	return r.db.WithContext(ctx).Delete(&update).Error
+}
+
+// FindBackerIDs adalah method dari repository untuk dapetin ID semua backer yang udah bayar di sebuah campaign.
+// Satu backer bisa punya banyak transaksi, jadi ID-nya dibikin unik.
+func (r *repository) FindBackerIDs(ctx context.Context, campaignID int) ([]int, error) {
+	var userIDs []int
+
+	err := r.db.WithContext(ctx).Table("transactions").
+		Where("campaign_id = ? AND status = ?", campaignID, paidTransactionStatus).
+		Distinct().
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return userIDs, err
+	}
+	return userIDs, nil
+}
+
+// IsBacker adalah method dari repository untuk ngecek user udah pernah dukung (dan bayar) campaign apa belum.
+func (r *repository) IsBacker(ctx context.Context, campaignID int, userID int) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Table("transactions").
+		Where("campaign_id = ? AND user_id = ? AND status = ?", campaignID, userID, paidTransactionStatus).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SaveImage adalah method dari repository untuk nyimpen gambar campaign baru.
+// Kalo gambarnya dijadiin gambar utama, gambar utama sebelumnya diturunin dulu biar cuma ada satu.
+func (r *repository) SaveImage(ctx context.Context, image CampaignImage) (CampaignImage, error) {
+	now := time.Now()
+	image.CreatedAt = now
+	image.UpdateAt = now
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if image.IsPrimary == 1 {
+			err := tx.Model(&CampaignImage{}).
+				Where("campaign_id = ?", image.CampaignID).
+				Update("is_primary", 0).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Create(&image).Error
+	})
+	if err != nil {
+		return image, err
+	}
+	return image, nil
+}
+
+// RecomputeTotals adalah method dari repository untuk ngitung ulang dana dan jumlah backer campaign dari transaksi yang lunas.
+// Dihitung langsung di satu query UPDATE biar pembayaran yang masuk barengan nggak ketimpa. campaignID 0 artinya semua campaign.
+// Balikin jumlah campaign yang angkanya beneran berubah. Campaign yang angkanya udah bener nggak ikut diubah,
+// soalnya MySQL ngitung baris yang berubah sedangkan Postgres dan SQLite ngitung semua baris yang cocok.
+func (r *repository) RecomputeTotals(ctx context.Context, campaignID int) (int64, error) {
+	paidAmount := gorm.Expr("(SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE transactions.campaign_id = campaigns.id AND transactions.status = ?)", paidTransactionStatus)
+	paidCount := gorm.Expr("(SELECT COUNT(*) FROM transactions WHERE transactions.campaign_id = campaigns.id AND transactions.status = ?)", paidTransactionStatus)
+
+	query := r.db.WithContext(ctx).Model(&Campaign{}).Where("(current_amount <> ? OR backer_count <> ?)", paidAmount, paidCount)
+	if campaignID != 0 {
+		query = query.Where("id = ?", campaignID)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"current_amount": paidAmount,
+		"backer_count":   paidCount,
+		"updated_at":     time.Now(),
+	})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,539 @@
+package campaign
+
+import (
+	"campaignku/config"
+	"campaignku/migration"
+	"campaignku/user"
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB bikin database SQLite di memori yang udah dimigrasi, biar query repository dites ke SQL beneran.
+// Koneksinya dibatesin satu, soalnya tiap koneksi ":memory:" punya database sendiri-sendiri.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	cfg := config.DatabaseConfig{Driver: config.DriverSQLite, Path: ":memory:"}
+	db, err := gorm.Open(sqlite.Open(cfg.DSN()), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gagal membuka database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("gagal mengambil koneksi database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("gagal menyiapkan migrasi: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("gagal menerapkan migrasi: %v", err)
+	}
+
+	return db
+}
+
+// testFixture nyiapin repository plus satu pemilik campaign.
+type testFixture struct {
+	db         *gorm.DB
+	repository *repository
+	owner      user.User
+}
+
+func newTestFixture(t *testing.T) testFixture {
+	t.Helper()
+
+	db := newTestDB(t)
+	owner, err := user.NewRepository(db).Save(context.Background(), user.User{Name: "Budi", Email: "budi@example.com", Role: user.RoleUser})
+	if err != nil {
+		t.Fatalf("gagal menyimpan pemilik campaign: %v", err)
+	}
+
+	return testFixture{db: db, repository: NewRepository(db), owner: owner}
+}
+
+// saveCampaign nyimpen campaign aktif yang berakhir seminggu lagi. Field yang diisi di campaign nimpa nilai default-nya.
+func (f testFixture) saveCampaign(t *testing.T, campaign Campaign) Campaign {
+	t.Helper()
+
+	now := time.Now()
+	if campaign.UserId == 0 {
+		campaign.UserId = f.owner.ID
+	}
+	if campaign.Slug == "" {
+		campaign.Slug = slugify(campaign.Name)
+	}
+	if campaign.Status == "" {
+		campaign.Status = StatusActive
+	}
+	if campaign.FundingMode == "" {
+		campaign.FundingMode = FundingFlexible
+	}
+	if campaign.StartDate.IsZero() {
+		campaign.StartDate = now.Add(-time.Hour)
+	}
+	if campaign.EndDate.IsZero() {
+		campaign.EndDate = now.AddDate(0, 0, 7)
+	}
+
+	saved, err := f.repository.Save(context.Background(), campaign)
+	if err != nil {
+		t.Fatalf("gagal menyimpan campaign %q: %v", campaign.Name, err)
+	}
+	return saved
+}
+
+// saveTransaction nyimpen transaksi langsung ke tabelnya. Package transaction nggak bisa dipake di sini karena dia yang bergantung ke package campaign.
+func (f testFixture) saveTransaction(t *testing.T, campaignID int, amount int, status string) {
+	t.Helper()
+
+	err := f.db.Exec(
+		"INSERT INTO transactions (campaign_id, user_id, amount, status, code, refund_error, shipping_street) VALUES (?, ?, ?, ?, ?, '', '')",
+		campaignID, f.owner.ID, amount, status, fmt.Sprintf("CK-%d-%d", campaignID, time.Now().UnixNano()),
+	).Error
+	if err != nil {
+		t.Fatalf("gagal menyimpan transaksi: %v", err)
+	}
+}
+
+// campaignIDs ngambil ID dari daftar campaign sesuai urutannya.
+func campaignIDs(campaigns []Campaign) []int {
+	IDs := make([]int, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		IDs = append(IDs, campaign.ID)
+	}
+	return IDs
+}
+
+func TestRepositoryFindByID(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+
+	category, err := f.repository.SaveCategory(ctx, Category{Name: "Pendidikan", Slug: "pendidikan"})
+	if err != nil {
+		t.Fatalf("SaveCategory: %v", err)
+	}
+
+	saved := f.saveCampaign(t, Campaign{Name: "Perpustakaan Keliling", GoalAmount: 1000, CategoryID: &category.ID})
+
+	tags, err := f.repository.FindOrCreateTags(ctx, []string{"Anak", "buku"})
+	if err != nil {
+		t.Fatalf("FindOrCreateTags: %v", err)
+	}
+	if err := f.repository.ReplaceTags(ctx, saved, tags); err != nil {
+		t.Fatalf("ReplaceTags: %v", err)
+	}
+	for _, amount := range []int{50000, 10000} {
+		if _, err := f.repository.SaveReward(ctx, Reward{CampaignID: saved.ID, Title: fmt.Sprintf("Reward %d", amount), MinimumAmount: amount}); err != nil {
+			t.Fatalf("SaveReward: %v", err)
+		}
+	}
+	if _, err := f.repository.SaveImage(ctx, CampaignImage{CampaignID: saved.ID, FileName: "a.png", IsPrimary: 1}); err != nil {
+		t.Fatalf("SaveImage: %v", err)
+	}
+
+	found, err := f.repository.FindByID(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if found.ID != saved.ID || found.Name != "Perpustakaan Keliling" || found.User.ID != f.owner.ID {
+		t.Errorf("FindByID = %+v, mau campaign %d milik %d", found, saved.ID, f.owner.ID)
+	}
+	if found.Category == nil || found.Category.Slug != "pendidikan" {
+		t.Errorf("Category = %+v, mau pendidikan", found.Category)
+	}
+	if len(found.Tags) != 2 || len(found.CampaignImages) != 1 {
+		t.Errorf("dapet %d tag dan %d gambar, mau 2 tag dan 1 gambar", len(found.Tags), len(found.CampaignImages))
+	}
+	if len(found.Rewards) != 2 || found.Rewards[0].MinimumAmount != 10000 {
+		t.Errorf("Rewards = %+v, mau 2 reward diurutin dari yang paling murah", found.Rewards)
+	}
+
+	missing, err := f.repository.FindByID(ctx, saved.ID+100)
+	if err != nil || missing.ID != 0 {
+		t.Errorf("FindByID campaign yang nggak ada = (%+v, %v), mau campaign kosong", missing, err)
+	}
+
+	bySlug, err := f.repository.FindBySlug(ctx, saved.Slug)
+	if err != nil || bySlug.ID != saved.ID {
+		t.Errorf("FindBySlug = (%d, %v), mau campaign %d", bySlug.ID, err, saved.ID)
+	}
+}
+
+func TestRepositoryUpdate(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+
+	saved := f.saveCampaign(t, Campaign{Name: "Sumur Bersih", GoalAmount: 1000})
+	if err := f.repository.AddFunds(ctx, saved.ID, 300, 2); err != nil {
+		t.Fatalf("AddFunds: %v", err)
+	}
+
+	// Angka di struct udah basi, Update nggak boleh nimpa dana dan backer yang ditambah barusan.
+	saved.Name = "Sumur Bersih untuk Desa"
+	saved.GoalAmount = 2000
+	if _, err := f.repository.Update(ctx, saved); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	found, err := f.repository.FindByID(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if found.Name != "Sumur Bersih untuk Desa" || found.GoalAmount != 2000 {
+		t.Errorf("perubahan nggak kesimpen: %+v", found)
+	}
+	if found.CurrentAmount != 300 || found.BackerCount != 2 {
+		t.Errorf("dana dan backer = (%d, %d), mau tetep (300, 2)", found.CurrentAmount, found.BackerCount)
+	}
+}
+
+func TestRepositorySaveDuplicateSlug(t *testing.T) {
+	f := newTestFixture(t)
+
+	f.saveCampaign(t, Campaign{Name: "Sumur Bersih", Slug: "sumur-bersih-1"})
+
+	_, err := f.repository.Save(context.Background(), Campaign{UserId: f.owner.ID, Name: "Sumur Bersih", Slug: "sumur-bersih-1", StartDate: time.Now(), EndDate: time.Now().Add(time.Hour)})
+	if err == nil {
+		t.Fatal("Save dengan slug yang udah dipake harusnya gagal")
+	}
+}
+
+func TestRepositoryFindAllFilters(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+
+	other, err := user.NewRepository(f.db).Save(ctx, user.User{Name: "Siti", Email: "siti@example.com"})
+	if err != nil {
+		t.Fatalf("gagal menyimpan pengguna lain: %v", err)
+	}
+	category, err := f.repository.SaveCategory(ctx, Category{Name: "Kesehatan", Slug: "kesehatan"})
+	if err != nil {
+		t.Fatalf("SaveCategory: %v", err)
+	}
+
+	small := f.saveCampaign(t, Campaign{Name: "Kecil", GoalAmount: 100, CategoryID: &category.ID})
+	large := f.saveCampaign(t, Campaign{Name: "Besar", GoalAmount: 10000, UserId: other.ID})
+	failed := f.saveCampaign(t, Campaign{Name: "Gagal", GoalAmount: 500, Status: StatusFailed})
+
+	if err := f.repository.AddFunds(ctx, small.ID, 150, 1); err != nil {
+		t.Fatalf("AddFunds: %v", err)
+	}
+	tags, err := f.repository.FindOrCreateTags(ctx, []string{"Papua"})
+	if err != nil {
+		t.Fatalf("FindOrCreateTags: %v", err)
+	}
+	if err := f.repository.ReplaceTags(ctx, large, tags); err != nil {
+		t.Fatalf("ReplaceTags: %v", err)
+	}
+
+	funded, notFunded := true, false
+	tests := []struct {
+		name  string
+		input GetCampaignsInput
+		want  []int
+	}{
+		{"tanpa filter", GetCampaignsInput{}, []int{small.ID, large.ID, failed.ID}},
+		{"pemilik", GetCampaignsInput{UserID: other.ID}, []int{large.ID}},
+		{"target minimal", GetCampaignsInput{MinGoal: 500}, []int{large.ID, failed.ID}},
+		{"target maksimal", GetCampaignsInput{MaxGoal: 500}, []int{small.ID, failed.ID}},
+		{"udah capai target", GetCampaignsInput{Funded: &funded}, []int{small.ID}},
+		{"belum capai target", GetCampaignsInput{Funded: &notFunded}, []int{large.ID, failed.ID}},
+		{"status", GetCampaignsInput{Status: StatusFailed}, []int{failed.ID}},
+		{"kategori", GetCampaignsInput{Category: "kesehatan"}, []int{small.ID}},
+		{"tag", GetCampaignsInput{Tag: "papua"}, []int{large.ID}},
+		{"tag yang nggak ada", GetCampaignsInput{Tag: "jakarta"}, []int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := test.input
+			input.Sort = SortMostBackers
+			input.Limit = 10
+
+			campaigns, total, err := f.repository.FindAll(ctx, input)
+			if err != nil {
+				t.Fatalf("FindAll: %v", err)
+			}
+
+			got := campaignIDs(campaigns)
+			sort.Ints(got)
+			want := append([]int{}, test.want...)
+			sort.Ints(want)
+
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("FindAll dapet campaign %v, mau %v", got, want)
+			}
+			if total != int64(len(want)) {
+				t.Errorf("total = %d, mau %d", total, len(want))
+			}
+		})
+	}
+}
+
+func TestRepositoryFindAllEndingSoon(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	now := time.Now()
+
+	later := f.saveCampaign(t, Campaign{Name: "Nanti", EndDate: now.AddDate(0, 0, 10)})
+	sooner := f.saveCampaign(t, Campaign{Name: "Sebentar Lagi", EndDate: now.AddDate(0, 0, 1)})
+	f.saveCampaign(t, Campaign{Name: "Udah Lewat", EndDate: now.Add(-time.Hour)})
+	f.saveCampaign(t, Campaign{Name: "Udah Ditutup", EndDate: now.Add(2 * time.Hour), Status: StatusSuccessful})
+
+	campaigns, total, err := f.repository.FindAll(ctx, GetCampaignsInput{Sort: SortEndingSoon, Limit: 10})
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+
+	want := []int{sooner.ID, later.ID}
+	if got := campaignIDs(campaigns); fmt.Sprint(got) != fmt.Sprint(want) || total != 2 {
+		t.Errorf("FindAll ending_soon = %v (total %d), mau cuma campaign aktif yang belum lewat %v", got, total, want)
+	}
+}
+
+func TestRepositoryFindAllCursorPagination(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	now := time.Now()
+
+	// Sebagian nilainya sengaja kembar, biar pemecah seri pake ID ikut kecoba.
+	seeds := []struct {
+		amount  int
+		backers int
+		endsIn  time.Duration
+	}{
+		{500, 3, 48 * time.Hour},
+		{100, 1, 24 * time.Hour},
+		{500, 3, 48 * time.Hour},
+		{900, 5, 72 * time.Hour},
+		{100, 2, 24 * time.Hour},
+		{0, 0, 96 * time.Hour},
+		{900, 1, 12 * time.Hour},
+	}
+	for i, seed := range seeds {
+		saved := f.saveCampaign(t, Campaign{Name: fmt.Sprintf("Campaign %d", i), GoalAmount: 1000, EndDate: now.Add(seed.endsIn).Truncate(time.Second)})
+		if err := f.repository.AddFunds(ctx, saved.ID, seed.amount, seed.backers); err != nil {
+			t.Fatalf("AddFunds: %v", err)
+		}
+	}
+
+	keys := map[string]func(Campaign) int64{
+		SortNewest:      func(c Campaign) int64 { return c.CreatedAt.UnixNano() },
+		SortMostFunded:  func(c Campaign) int64 { return int64(c.CurrentAmount) },
+		SortMostBackers: func(c Campaign) int64 { return int64(c.BackerCount) },
+		SortEndingSoon:  func(c Campaign) int64 { return c.EndDate.UnixNano() },
+	}
+
+	for sortName := range sortOptions {
+		t.Run(sortName, func(t *testing.T) {
+			all, total, err := f.repository.FindAll(ctx, GetCampaignsInput{Sort: sortName, Limit: 100})
+			if err != nil {
+				t.Fatalf("FindAll satu halaman: %v", err)
+			}
+			if len(all) != len(seeds) || total != int64(len(seeds)) {
+				t.Fatalf("FindAll satu halaman dapet %d campaign (total %d), mau %d", len(all), total, len(seeds))
+			}
+
+			// Hasil satu halaman harus urut sesuai kolomnya, yang kembar diurutin pake ID.
+			key, desc := keys[sortName], sortOptions[sortName].desc
+			for i := 1; i < len(all); i++ {
+				previous, current := key(all[i-1]), key(all[i])
+				if previous == current {
+					if (desc && all[i-1].ID < all[i].ID) || (!desc && all[i-1].ID > all[i].ID) {
+						t.Errorf("campaign %d dan %d nilainya kembar tapi ID-nya nggak urut", all[i-1].ID, all[i].ID)
+					}
+					continue
+				}
+				if (desc && previous < current) || (!desc && previous > current) {
+					t.Errorf("campaign %d dan %d nggak urut", all[i-1].ID, all[i].ID)
+				}
+			}
+
+			// Jalan pake cursor dua-dua harus dapet urutan yang sama persis, tanpa ada yang dobel atau kelewat.
+			var paged []int
+			input := GetCampaignsInput{Sort: sortName, Limit: 2}
+			for page := 0; page <= len(seeds); page++ {
+				campaigns, _, err := f.repository.FindAll(ctx, input)
+				if err != nil {
+					t.Fatalf("FindAll halaman %d: %v", page+1, err)
+				}
+				if len(campaigns) <= input.Limit {
+					paged = append(paged, campaignIDs(campaigns)...)
+					break
+				}
+
+				campaigns = campaigns[:input.Limit]
+				paged = append(paged, campaignIDs(campaigns)...)
+				input.Cursor = encodeCursor(campaigns[len(campaigns)-1], sortName)
+			}
+
+			if want := campaignIDs(all); fmt.Sprint(paged) != fmt.Sprint(want) {
+				t.Errorf("pake cursor dapet %v, mau %v", paged, want)
+			}
+		})
+	}
+}
+
+func TestRepositoryFindAllInvalidCursor(t *testing.T) {
+	f := newTestFixture(t)
+
+	_, _, err := f.repository.FindAll(context.Background(), GetCampaignsInput{Sort: SortNewest, Limit: 10, Cursor: "bukan-cursor"})
+	if err != ErrInvalidCursor {
+		t.Errorf("FindAll dengan cursor rusak = %v, mau ErrInvalidCursor", err)
+	}
+}
+
+func TestRepositoryAddFunds(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+
+	saved := f.saveCampaign(t, Campaign{Name: "Sumur Bersih", GoalAmount: 1000})
+
+	steps := []struct {
+		amount      int
+		backers     int
+		wantAmount  int
+		wantBackers int
+	}{
+		{100, 1, 100, 1},
+		{250, 1, 350, 2},
+		{-100, -1, 250, 1}, // Refund.
+	}
+	for _, step := range steps {
+		if err := f.repository.AddFunds(ctx, saved.ID, step.amount, step.backers); err != nil {
+			t.Fatalf("AddFunds(%d, %d): %v", step.amount, step.backers, err)
+		}
+
+		found, err := f.repository.FindByID(ctx, saved.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.CurrentAmount != step.wantAmount || found.BackerCount != step.wantBackers {
+			t.Errorf("setelah AddFunds(%d, %d) dana dan backer = (%d, %d), mau (%d, %d)",
+				step.amount, step.backers, found.CurrentAmount, found.BackerCount, step.wantAmount, step.wantBackers)
+		}
+	}
+}
+
+func TestRepositoryReserveReward(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+
+	saved := f.saveCampaign(t, Campaign{Name: "Kaos Komunitas"})
+	limited, err := f.repository.SaveReward(ctx, Reward{CampaignID: saved.ID, Title: "Kaos", MinimumAmount: 100, Quantity: 2})
+	if err != nil {
+		t.Fatalf("SaveReward: %v", err)
+	}
+	unlimited, err := f.repository.SaveReward(ctx, Reward{CampaignID: saved.ID, Title: "Ucapan terima kasih", MinimumAmount: 10})
+	if err != nil {
+		t.Fatalf("SaveReward: %v", err)
+	}
+
+	reserve := func(rewardID int, want bool) {
+		t.Helper()
+		reserved, err := f.repository.ReserveReward(ctx, rewardID)
+		if err != nil {
+			t.Fatalf("ReserveReward: %v", err)
+		}
+		if reserved != want {
+			t.Errorf("ReserveReward(%d) = %v, mau %v", rewardID, reserved, want)
+		}
+	}
+	reservedCount := func(rewardID int, want int) {
+		t.Helper()
+		reward, err := f.repository.FindRewardByID(ctx, rewardID)
+		if err != nil {
+			t.Fatalf("FindRewardByID: %v", err)
+		}
+		if reward.ReservedCount != want {
+			t.Errorf("ReservedCount reward %d = %d, mau %d", rewardID, reward.ReservedCount, want)
+		}
+	}
+
+	// Stoknya dua, pesanan ketiga ditolak sampai ada yang dikembaliin.
+	reserve(limited.ID, true)
+	reserve(limited.ID, true)
+	reserve(limited.ID, false)
+	reservedCount(limited.ID, 2)
+
+	if err := f.repository.ReleaseReward(ctx, limited.ID); err != nil {
+		t.Fatalf("ReleaseReward: %v", err)
+	}
+	reservedCount(limited.ID, 1)
+	reserve(limited.ID, true)
+
+	// Reward tanpa batas selalu bisa dipesan.
+	for i := 0; i < 3; i++ {
+		reserve(unlimited.ID, true)
+	}
+	reservedCount(unlimited.ID, 3)
+
+	// Ngembaliin stok yang nggak pernah dipesan nggak bikin angkanya minus.
+	empty, err := f.repository.SaveReward(ctx, Reward{CampaignID: saved.ID, Title: "Stiker", MinimumAmount: 5, Quantity: 1})
+	if err != nil {
+		t.Fatalf("SaveReward: %v", err)
+	}
+	if err := f.repository.ReleaseReward(ctx, empty.ID); err != nil {
+		t.Fatalf("ReleaseReward: %v", err)
+	}
+	reservedCount(empty.ID, 0)
+}
+
+func TestRepositoryRecomputeTotals(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+
+	first := f.saveCampaign(t, Campaign{Name: "Pertama"})
+	second := f.saveCampaign(t, Campaign{Name: "Kedua"})
+	untouched := f.saveCampaign(t, Campaign{Name: "Tanpa Transaksi"})
+
+	f.saveTransaction(t, first.ID, 100, paidTransactionStatus)
+	f.saveTransaction(t, first.ID, 250, paidTransactionStatus)
+	f.saveTransaction(t, first.ID, 999, "pending")
+	f.saveTransaction(t, second.ID, 400, paidTransactionStatus)
+
+	// Angka campaign kedua sengaja dibikin nggak sinkron.
+	if err := f.repository.AddFunds(ctx, second.ID, 50, 3); err != nil {
+		t.Fatalf("AddFunds: %v", err)
+	}
+
+	fixed, err := f.repository.RecomputeTotals(ctx, second.ID)
+	if err != nil || fixed != 1 {
+		t.Fatalf("RecomputeTotals satu campaign = (%d, %v), mau (1, nil)", fixed, err)
+	}
+
+	fixed, err = f.repository.RecomputeTotals(ctx, 0)
+	if err != nil || fixed != 1 {
+		t.Fatalf("RecomputeTotals semua campaign = (%d, %v), mau (1, nil) karena cuma campaign pertama yang masih salah", fixed, err)
+	}
+
+	// Dijalanin lagi nggak ada yang berubah.
+	fixed, err = f.repository.RecomputeTotals(ctx, 0)
+	if err != nil || fixed != 0 {
+		t.Fatalf("RecomputeTotals kedua kali = (%d, %v), mau (0, nil)", fixed, err)
+	}
+
+	want := map[int][2]int{first.ID: {350, 2}, second.ID: {400, 1}, untouched.ID: {0, 0}}
+	for campaignID, totals := range want {
+		found, err := f.repository.FindByID(ctx, campaignID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if found.CurrentAmount != totals[0] || found.BackerCount != totals[1] {
+			t.Errorf("campaign %d dana dan backer = (%d, %d), mau (%d, %d)",
+				campaignID, found.CurrentAmount, found.BackerCount, totals[0], totals[1])
+		}
+	}
+}
@@ -1,35 +1,749 @@
 package campaign
 
+import (
+	"campaignku/helper"
+	"campaignku/notification"
+	"campaignku/pubsub"
+	"campaignku/search"
+	"campaignku/user"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Topik pubsub yang dikirim service campaign (dan transaksi), atas nama pemilik campaign.
+const (
+	TopicCampaignFunded = "campaign.funded" // Campaign baru aja capai target dananya.
+	TopicCampaignClosed = "campaign.closed" // Campaign ditutup karena udah lewat tanggal akhirnya.
+)
+
+// Batas default jumlah campaign per halaman dan per hasil pencarian.
+const (
+	defaultLimit       = 10
+	defaultSearchLimit = 20
+)
+
+// SearchWeights adalah bobot tiap field campaign di index pencarian. Nama campaign paling penting.
+var SearchWeights = map[string]float64{
+	"name":              3,
+	"short_description": 2,
+	"description":       1,
+}
+
+// Error yang bisa dibalikin sama service campaign.
+var (
+	ErrCampaignNotFound = errors.New("campaign tidak ditemukan")
+	ErrNotOwner         = errors.New("bukan pemilik campaign")
+	ErrCategoryNotFound = errors.New("kategori tidak ditemukan")
+	ErrCategoryExists   = errors.New("kategori dengan nama tersebut sudah ada")
+	ErrCampaignClosed   = errors.New("campaign sudah ditutup")
+	ErrInvalidSchedule  = errors.New("tanggal akhir campaign harus di masa depan")
+	ErrInvalidStartDate = errors.New("tanggal mulai campaign tidak boleh di masa lalu")
+	ErrCampaignStarted  = errors.New("target dana dan tanggal mulai tidak bisa diubah setelah campaign dimulai")
+	ErrRewardNotFound   = errors.New("reward tidak ditemukan")
+	ErrRewardSoldOut    = errors.New("stok reward sudah habis")
+	ErrRewardInUse      = errors.New("reward sudah dipilih backer dan tidak bisa dihapus")
+	ErrInvalidQuantity  = errors.New("jumlah reward tidak boleh lebih kecil dari yang sudah dipesan")
+	ErrUpdateNotFound   = errors.New("kabar terbaru tidak ditemukan")
+)
+
 // Service adalah interface yang mendefinisikan fungsi yang harus ada di service campaign.
 type Service interface {
-	GetCampaigns(userID int) ([]Campaign, error) // Fungsi buat dapetin campaign berdasarkan userID.
+	GetCampaigns(ctx context.Context, input GetCampaignsInput) ([]Campaign, helper.Pagination, error)                                            // Fungsi buat dapetin campaign sesuai filter dan paginasi.
+	CreateCampaign(ctx context.Context, input CreateCampaignInput) (Campaign, error)                                                             // Fungsi buat bikin campaign baru.
+	UpdateCampaign(ctx context.Context, inputID GetCampaignDetailInput, input CreateCampaignInput) (Campaign, error)                             // Fungsi buat ngubah campaign, cuma boleh sama pemiliknya.
+	SearchCampaigns(ctx context.Context, input SearchCampaignsInput) ([]SearchResult, error)                                                     // Fungsi buat nyari campaign pake teks.
+	RebuildSearchIndex(ctx context.Context) error                                                                                                // Fungsi buat ngisi ulang index pencarian dari database.
+	GetCampaignByID(ctx context.Context, input GetCampaignDetailInput) (Campaign, error)                                                         // Fungsi buat dapetin detail satu campaign.
+	GetCategories(ctx context.Context) ([]CategoryWithCount, error)                                                                              // Fungsi buat dapetin semua kategori plus jumlah campaign-nya.
+	CreateCategory(ctx context.Context, input CategoryInput) (Category, error)                                                                   // Fungsi buat bikin kategori baru, khusus admin.
+	UpdateCategory(ctx context.Context, inputID GetCategoryInput, input CategoryInput) (Category, error)                                         // Fungsi buat ngubah kategori, khusus admin.
+	DeleteCategory(ctx context.Context, inputID GetCategoryInput) error                                                                          // Fungsi buat ngapus kategori, khusus admin.
+	CloseExpiredCampaigns(ctx context.Context, now time.Time) ([]Campaign, error)                                                                // Fungsi buat nutup campaign yang udah lewat tanggal akhirnya.
+	CreateReward(ctx context.Context, inputID GetCampaignDetailInput, input RewardInput) (Reward, error)                                         // Fungsi buat nambah reward, cuma boleh sama pemilik campaign.
+	UpdateReward(ctx context.Context, inputID GetRewardInput, input RewardInput) (Reward, error)                                                 // Fungsi buat ngubah reward, cuma boleh sama pemilik campaign.
+	DeleteReward(ctx context.Context, inputID GetRewardInput, user user.User) error                                                              // Fungsi buat ngapus reward yang belum dipilih backer.
+	GetCampaignUpdates(ctx context.Context, inputID GetCampaignDetailInput, viewer *user.User) ([]CampaignUpdate, bool, error)                   // Fungsi buat dapetin kabar terbaru campaign, plus boleh nggaknya baca yang khusus backer.
+	CreateCampaignUpdate(ctx context.Context, inputID GetCampaignDetailInput, input CampaignUpdateInput) (CampaignUpdate, error)                 // Fungsi buat nerbitin kabar terbaru dan ngabarin semua backer.
+	UpdateCampaignUpdate(ctx context.Context, inputID GetCampaignUpdateInput, input CampaignUpdateInput) (CampaignUpdate, error)                 // Fungsi buat ngubah kabar terbaru, cuma boleh sama pemilik campaign.
+	DeleteCampaignUpdate(ctx context.Context, inputID GetCampaignUpdateInput, user user.User) error                                              // Fungsi buat ngapus kabar terbaru, cuma boleh sama pemilik campaign.
+	SaveCampaignImage(ctx context.Context, inputID GetCampaignDetailInput, input CampaignImageInput, fileLocation string) (CampaignImage, error) // Fungsi buat nyimpen gambar campaign yang udah diunggah, cuma boleh sama pemilik campaign.
+	RecomputeTotals(ctx context.Context, campaignID int) (int64, error)                                                                          // Fungsi buat ngitung ulang dana dan jumlah backer dari transaksi yang lunas.
+}
+
+// SearchResult adalah satu campaign hasil pencarian, lengkap sama skor dan potongan teksnya.
+type SearchResult struct {
+	Campaign   Campaign
+	Score      float64
+	Highlights map[string]string
 }
 
 // service adalah struct yang implementasi dari Service.
 type service struct {
-	repository Repository // Ini tempat nyimpen data, kaya database gitu.
+	repository          Repository           // Ini tempat nyimpen data, kaya database gitu.
+	index               search.Index         // Index pencarian, harus selalu sinkron sama database.
+	notificationService notification.Service // Buat ngabarin backer kalo ada kabar terbaru.
+	publisher           pubsub.Publisher     // Buat nyebarin event campaign ke sistem lain, misal webhook.
 }
 
 // NewService adalah fungsi pembuat service baru.
-func NewService(repository Repository) *service {
-	return &service{repository} // Balikin instance service yang baru dengan repository.
+func NewService(repository Repository, index search.Index, notificationService notification.Service, publisher pubsub.Publisher) *service {
+	return &service{repository, index, notificationService, publisher} // Balikin instance service yang baru dengan semua dependensinya.
 }
 
 // GetCampaigns adalah method dari service buat dapetin campaign.
-func (s *service) GetCampaigns(userID int) ([]Campaign, error) {
-	// Cek dulu, kalo userID nya nggak 0, berarti kita cari berdasarkan userID.
-	if userID != 0 {
-		campaigns, err := s.repository.FindByUserID(userID)
+// Campaign difilter, diurutkan, terus dipotong per halaman. Info paginasinya ikut dibalikin.
+func (s *service) GetCampaigns(ctx context.Context, input GetCampaignsInput) ([]Campaign, helper.Pagination, error) {
+	// Isi nilai default kalo client nggak ngirim.
+	if input.Sort == "" {
+		input.Sort = SortNewest
+	}
+	if input.Limit == 0 {
+		input.Limit = defaultLimit
+	}
+	if input.Page == 0 {
+		input.Page = 1
+	}
+
+	pagination := helper.Pagination{Limit: input.Limit}
+
+	campaigns, total, err := s.repository.FindAll(ctx, input)
+	if err != nil {
+		return campaigns, pagination, err // Kalo ada error, langsung balikin errornya.
+	}
+	pagination.Total = total
+
+	// Repository ngambil satu data lebih, jadi kalo kelebihan berarti masih ada halaman berikutnya.
+	if len(campaigns) > input.Limit {
+		campaigns = campaigns[:input.Limit]
+		pagination.HasMore = true
+		pagination.NextCursor = encodeCursor(campaigns[len(campaigns)-1], input.Sort)
+	}
+
+	// Nomor halaman cuma relevan kalo client nggak pake cursor.
+	if input.Cursor == "" {
+		pagination.Page = input.Page
+	}
+
+	return campaigns, pagination, nil
+}
+
+// CreateCampaign adalah method dari service buat bikin campaign baru atas nama user yang login.
+func (s *service) CreateCampaign(ctx context.Context, input CreateCampaignInput) (Campaign, error) {
+	campaign := Campaign{}
+	campaign.UserId = input.User.ID
+	campaign.Name = input.Name
+	campaign.ShortDescription = input.ShortDescription
+	campaign.Description = input.Description
+	campaign.GoalAmount = input.GoalAmount
+	campaign.CategoryID = input.CategoryID
+	campaign.StartDate = input.StartDate
+	campaign.EndDate = input.EndDate
+	campaign.Status = StatusActive
+	campaign.FundingMode = input.FundingMode
+	if campaign.FundingMode == "" {
+		campaign.FundingMode = FundingFlexible
+	}
+
+	if !input.EndDate.After(time.Now()) {
+		return campaign, ErrInvalidSchedule
+	}
+
+	if err := s.checkCategory(ctx, input.CategoryID); err != nil {
+		return campaign, err
+	}
+
+	slug, err := s.uniqueSlug(ctx, fmt.Sprintf("%s %d", input.Name, input.User.ID))
+	if err != nil {
+		return campaign, err
+	}
+	campaign.Slug = slug
+
+	newCampaign, err := s.repository.Save(ctx, campaign)
+	if err != nil {
+		return newCampaign, err
+	}
+
+	return s.saveTags(ctx, newCampaign, input.Tags)
+}
+
+// UpdateCampaign adalah method dari service buat ngubah campaign. Cuma pemilik campaign yang boleh.
+func (s *service) UpdateCampaign(ctx context.Context, inputID GetCampaignDetailInput, input CreateCampaignInput) (Campaign, error) {
+	campaign, err := s.repository.FindByID(ctx, inputID.ID)
+	if err != nil {
+		return campaign, err
+	}
+	if campaign.ID == 0 {
+		return campaign, ErrCampaignNotFound
+	}
+	if campaign.UserId != input.User.ID {
+		return campaign, ErrNotOwner
+	}
+	if campaign.Status != StatusActive {
+		return campaign, ErrCampaignClosed
+	}
+
+	now := time.Now()
+	if !input.EndDate.After(now) {
+		return campaign, ErrInvalidSchedule
+	}
+
+	// Target dana, tanggal mulai, dan mode pendanaan cuma boleh diubah selama campaign belum mulai,
+	// biar backer yang udah bayar nggak ketemu aturan main yang beda dari waktu mereka dukung.
+	if now.Before(campaign.StartDate) {
+		if input.StartDate.Before(now) {
+			return campaign, ErrInvalidStartDate
+		}
+		campaign.StartDate = input.StartDate
+		campaign.GoalAmount = input.GoalAmount
+		if input.FundingMode != "" {
+			campaign.FundingMode = input.FundingMode
+		}
+	} else if input.GoalAmount != campaign.GoalAmount || !input.StartDate.Equal(campaign.StartDate) {
+		return campaign, ErrCampaignStarted
+	}
+	campaign.EndDate = input.EndDate
+	campaign.Name = input.Name
+	campaign.ShortDescription = input.ShortDescription
+	campaign.Description = input.Description
+	campaign.CategoryID = input.CategoryID
+
+	if err := s.checkCategory(ctx, input.CategoryID); err != nil {
+		return campaign, err
+	}
+
+	updatedCampaign, err := s.repository.Update(ctx, campaign)
+	if err != nil {
+		return updatedCampaign, err
+	}
+
+	return s.saveTags(ctx, updatedCampaign, input.Tags)
+}
+
+// GetCampaignByID adalah method dari service buat dapetin detail satu campaign.
+func (s *service) GetCampaignByID(ctx context.Context, input GetCampaignDetailInput) (Campaign, error) {
+	campaign, err := s.repository.FindByID(ctx, input.ID)
+	if err != nil {
+		return campaign, err
+	}
+	if campaign.ID == 0 {
+		return campaign, ErrCampaignNotFound
+	}
+	return campaign, nil
+}
+
+// GetCategories adalah method dari service buat dapetin semua kategori plus jumlah campaign-nya.
+func (s *service) GetCategories(ctx context.Context) ([]CategoryWithCount, error) {
+	return s.repository.FindCategories(ctx)
+}
+
+// CreateCategory adalah method dari service buat bikin kategori baru. Slug dibikin dari namanya.
+func (s *service) CreateCategory(ctx context.Context, input CategoryInput) (Category, error) {
+	category := Category{}
+	category.Name = input.Name
+	category.Slug = slugify(input.Name)
+	category.Icon = input.Icon
+
+	existing, err := s.repository.FindCategoryBySlug(ctx, category.Slug)
+	if err != nil {
+		return category, err
+	}
+	if existing.ID != 0 {
+		return category, ErrCategoryExists
+	}
+
+	return s.repository.SaveCategory(ctx, category)
+}
+
+// UpdateCategory adalah method dari service buat ngubah nama dan ikon kategori.
+func (s *service) UpdateCategory(ctx context.Context, inputID GetCategoryInput, input CategoryInput) (Category, error) {
+	category, err := s.repository.FindCategoryByID(ctx, inputID.ID)
+	if err != nil {
+		return category, err
+	}
+	if category.ID == 0 {
+		return category, ErrCategoryNotFound
+	}
+
+	slug := slugify(input.Name)
+	existing, err := s.repository.FindCategoryBySlug(ctx, slug)
+	if err != nil {
+		return category, err
+	}
+	if existing.ID != 0 && existing.ID != category.ID {
+		return category, ErrCategoryExists
+	}
+
+	category.Name = input.Name
+	category.Slug = slug
+	category.Icon = input.Icon
+
+	return s.repository.UpdateCategory(ctx, category)
+}
+
+// DeleteCategory adalah method dari service buat ngapus kategori.
+func (s *service) DeleteCategory(ctx context.Context, inputID GetCategoryInput) error {
+	category, err := s.repository.FindCategoryByID(ctx, inputID.ID)
+	if err != nil {
+		return err
+	}
+	if category.ID == 0 {
+		return ErrCategoryNotFound
+	}
+
+	return s.repository.DeleteCategory(ctx, category)
+}
+
+// CloseExpiredCampaigns adalah method dari service buat nutup semua campaign aktif yang udah lewat tanggal akhirnya.
+// Campaign yang dananya udah capai GoalAmount jadi successful, sisanya failed. Yang dibalikin cuma campaign yang beneran ditutup di panggilan ini.
+func (s *service) CloseExpiredCampaigns(ctx context.Context, now time.Time) ([]Campaign, error) {
+	expired, err := s.repository.FindExpired(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := []Campaign{}
+	for _, campaign := range expired {
+		campaign.Status = StatusFailed
+		if campaign.CurrentAmount >= campaign.GoalAmount {
+			campaign.Status = StatusSuccessful
+		}
+		closedAt := now
+		campaign.ClosedAt = &closedAt
+
+		ok, err := s.repository.Close(ctx, campaign)
+		if err != nil {
+			return closed, err
+		}
+		if ok {
+			closed = append(closed, campaign)
+			s.publish(ctx, TopicCampaignClosed, campaign)
+		}
+	}
+
+	return closed, nil
+}
+
+// publish ngirim event campaign ke sebuah topik atas nama pemilik campaign. Kalo gagal cuma dicatat aja.
+func (s *service) publish(ctx context.Context, topic string, campaign Campaign) {
+	payload, err := json.Marshal(FormatCampaign(campaign))
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal menyusun event", "topic", topic, "error", err)
+		return
+	}
+
+	event := pubsub.Event{Type: topic, UserID: campaign.UserId, Payload: payload}
+	if err := s.publisher.Publish(ctx, topic, event); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim event", "topic", topic, "error", err)
+	}
+}
+
+// CreateReward adalah method dari service buat nambah reward ke campaign.
+func (s *service) CreateReward(ctx context.Context, inputID GetCampaignDetailInput, input RewardInput) (Reward, error) {
+	reward := Reward{}
+
+	campaign, err := s.findOwnedCampaign(ctx, inputID.ID, input.User)
+	if err != nil {
+		return reward, err
+	}
+
+	reward.CampaignID = campaign.ID
+	reward.Title = input.Title
+	reward.Description = input.Description
+	reward.MinimumAmount = input.MinimumAmount
+	reward.Quantity = input.Quantity
+	reward.EstimatedDelivery = input.EstimatedDelivery
+	reward.RequiresShipping = input.RequiresShipping
+
+	return s.repository.SaveReward(ctx, reward)
+}
+
+// UpdateReward adalah method dari service buat ngubah reward.
+// Jumlah reward nggak boleh dikurangin sampai di bawah yang udah dipesan backer.
+func (s *service) UpdateReward(ctx context.Context, inputID GetRewardInput, input RewardInput) (Reward, error) {
+	reward, err := s.findOwnedReward(ctx, inputID, input.User)
+	if err != nil {
+		return reward, err
+	}
+
+	if input.Quantity > 0 && input.Quantity < reward.ReservedCount {
+		return reward, ErrInvalidQuantity
+	}
+
+	reward.Title = input.Title
+	reward.Description = input.Description
+	reward.MinimumAmount = input.MinimumAmount
+	reward.Quantity = input.Quantity
+	reward.EstimatedDelivery = input.EstimatedDelivery
+	reward.RequiresShipping = input.RequiresShipping
+
+	return s.repository.UpdateReward(ctx, reward)
+}
+
+// DeleteReward adalah method dari service buat ngapus reward yang belum dipilih backer mana pun.
+func (s *service) DeleteReward(ctx context.Context, inputID GetRewardInput, user user.User) error {
+	reward, err := s.findOwnedReward(ctx, inputID, user)
+	if err != nil {
+		return err
+	}
+
+	if reward.ReservedCount > 0 {
+		return ErrRewardInUse
+	}
+
+	return s.repository.DeleteReward(ctx, reward)
+}
+
+// findOwnedCampaign ngambil campaign dan mastiin user-nya pemilik campaign itu.
+func (s *service) findOwnedCampaign(ctx context.Context, campaignID int, user user.User) (Campaign, error) {
+	campaign, err := s.repository.FindByID(ctx, campaignID)
+	if err != nil {
+		return campaign, err
+	}
+	if campaign.ID == 0 {
+		return campaign, ErrCampaignNotFound
+	}
+	if campaign.UserId != user.ID {
+		return campaign, ErrNotOwner
+	}
+	return campaign, nil
+}
+
+// findOwnedReward ngambil reward dan mastiin reward-nya milik campaign punya user itu.
+func (s *service) findOwnedReward(ctx context.Context, inputID GetRewardInput, user user.User) (Reward, error) {
+	campaign, err := s.findOwnedCampaign(ctx, inputID.CampaignID, user)
+	if err != nil {
+		return Reward{}, err
+	}
+
+	reward, err := s.repository.FindRewardByID(ctx, inputID.ID)
+	if err != nil {
+		return reward, err
+	}
+	if reward.ID == 0 || reward.CampaignID != campaign.ID {
+		return reward, ErrRewardNotFound
+	}
+	return reward, nil
+}
+
+// checkCategory mastiin kategori yang dipilih beneran ada. Campaign boleh nggak punya kategori.
+func (s *service) checkCategory(ctx context.Context, categoryID *int) error {
+	if categoryID == nil {
+		return nil
+	}
+
+	category, err := s.repository.FindCategoryByID(ctx, *categoryID)
+	if err != nil {
+		return err
+	}
+	if category.ID == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+// saveTags nyimpen tag campaign, terus ngambil ulang campaign-nya biar relasinya lengkap dan index pencarian ikut diperbarui.
+func (s *service) saveTags(ctx context.Context, campaign Campaign, names []string) (Campaign, error) {
+	tags, err := s.repository.FindOrCreateTags(ctx, names)
+	if err != nil {
+		return campaign, err
+	}
+
+	err = s.repository.ReplaceTags(ctx, campaign, tags)
+	if err != nil {
+		return campaign, err
+	}
+
+	savedCampaign, err := s.repository.FindByID(ctx, campaign.ID)
+	if err != nil {
+		return campaign, err
+	}
+
+	s.indexCampaign(ctx, savedCampaign)
+	return savedCampaign, nil
+}
+
+// SearchCampaigns adalah method dari service buat nyari campaign berdasarkan nama dan deskripsinya.
+func (s *service) SearchCampaigns(ctx context.Context, input SearchCampaignsInput) ([]SearchResult, error) {
+	if input.Limit == 0 {
+		input.Limit = defaultSearchLimit
+	}
+
+	hits, err := s.index.Search(input.Query, input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	IDs := make([]int, 0, len(hits))
+	for _, hit := range hits {
+		IDs = append(IDs, hit.ID)
+	}
+
+	campaigns, err := s.repository.FindByIDs(ctx, IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Campaign yang udah kehapus dari database tapi masih nyangkut di index dilewatin.
+	byID := make(map[int]Campaign, len(campaigns))
+	for _, campaign := range campaigns {
+		byID[campaign.ID] = campaign
+	}
+
+	results := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		campaign, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, SearchResult{campaign, hit.Score, hit.Highlights})
+	}
+
+	return results, nil
+}
+
+// RebuildSearchIndex adalah method dari service buat ngisi index pencarian dari semua campaign di database.
+// Dipanggil waktu aplikasi baru nyala, karena index bawaan cuma hidup di memori.
+func (s *service) RebuildSearchIndex(ctx context.Context) error {
+	return s.repository.FindInBatches(ctx, 100, func(campaigns []Campaign) error {
+		for _, campaign := range campaigns {
+			if err := s.index.Index(searchDocument(campaign)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// indexCampaign masukin campaign ke index pencarian.
+// Kalo gagal cuma dicatat aja, karena campaign-nya sendiri udah kesimpen di database.
+func (s *service) indexCampaign(ctx context.Context, campaign Campaign) {
+	if err := s.index.Index(searchDocument(campaign)); err != nil {
+		slog.ErrorContext(ctx, "gagal mengindex campaign", "campaign_id", campaign.ID, "error", err)
+	}
+}
+
+// searchDocument ngubah campaign jadi dokumen buat index pencarian.
+func searchDocument(campaign Campaign) search.Document {
+	return search.Document{
+		ID: campaign.ID,
+		Fields: map[string]string{
+			"name":              campaign.Name,
+			"short_description": campaign.ShortDescription,
+			"description":       campaign.Description,
+		},
+	}
+}
+
+// uniqueSlug bikin slug campaign dari text. Slug dibikin dari nama plus ID pemilik biar nggak gampang bentrok,
+// tapi pemilik yang sama bisa aja bikin dua campaign dengan nama yang sama, jadi kalo slug-nya udah kepake
+// ditambahin angka di belakangnya, misal "galang-dana-1-2".
+func (s *service) uniqueSlug(ctx context.Context, text string) (string, error) {
+	base := slugify(text)
+	slug := base
+
+	for suffix := 2; ; suffix++ {
+		existing, err := s.repository.FindBySlug(ctx, slug)
 		if err != nil {
-			return campaigns, err // Kalo ada error, langsung balikin errornya.
+			return slug, err
+		}
+		if existing.ID == 0 {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, suffix)
+	}
+}
+
+// slugify ngubah teks jadi slug huruf kecil yang dipisah tanda strip, misal "Galang Dana 1" jadi "galang-dana-1".
+func slugify(text string) string {
+	var builder strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && builder.Len() > 0 {
+			builder.WriteRune('-')
+			dash = true
 		}
-		return campaigns, nil // Kalo nggak ada error, balikin campaignnya.
 	}
 
-	// Kalo userID nya 0, berarti kita cari semua campaign yang ada.
-	campaigns, err := s.repository.FindAll()
+	return strings.TrimSuffix(builder.String(), "-")
+}
+
+// GetCampaignUpdates adalah method dari service buat dapetin kabar terbaru sebuah campaign.
+// Selain daftarnya, method ini juga balikin apakah viewer boleh baca kabar yang khusus backer.
+// Viewer boleh nil kalo yang buka belum login.
+func (s *service) GetCampaignUpdates(ctx context.Context, inputID GetCampaignDetailInput, viewer *user.User) ([]CampaignUpdate, bool, error) {
+	campaign, err := s.repository.FindByID(ctx, inputID.ID)
 	if err != nil {
-		return campaigns, err // Sama, kalo ada error, balikin errornya.
+		return nil, false, err
+	}
+	if campaign.ID == 0 {
+		return nil, false, ErrCampaignNotFound
 	}
-	return campaigns, nil // Kalo nggak ada error, balikin semua campaign.
+
+	updates, err := s.repository.FindUpdatesByCampaignID(ctx, campaign.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	canViewBackersOnly, err := s.canViewBackersOnly(ctx, campaign, viewer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return updates, canViewBackersOnly, nil
+}
+
+// CreateCampaignUpdate adalah method dari service buat nerbitin kabar terbaru campaign.
+// Setelah kesimpen, semua backer yang udah bayar dapet notifikasi.
+func (s *service) CreateCampaignUpdate(ctx context.Context, inputID GetCampaignDetailInput, input CampaignUpdateInput) (CampaignUpdate, error) {
+	update := CampaignUpdate{}
+
+	campaign, err := s.findOwnedCampaign(ctx, inputID.ID, input.User)
+	if err != nil {
+		return update, err
+	}
+
+	update.CampaignID = campaign.ID
+	update.Title = input.Title
+	update.Body = input.Body
+	update.Visibility = input.Visibility
+	if update.Visibility == "" {
+		update.Visibility = VisibilityPublic
+	}
+
+	newUpdate, err := s.repository.SaveUpdate(ctx, update)
+	if err != nil {
+		return newUpdate, err
+	}
+
+	s.notifyBackers(ctx, campaign, newUpdate)
+	return newUpdate, nil
+}
+
+// UpdateCampaignUpdate adalah method dari service buat ngubah kabar terbaru.
+// Perubahan nggak dikabarin ulang ke backer biar mereka nggak kebanjiran notifikasi.
+func (s *service) UpdateCampaignUpdate(ctx context.Context, inputID GetCampaignUpdateInput, input CampaignUpdateInput) (CampaignUpdate, error) {
+	update, err := s.findOwnedUpdate(ctx, inputID, input.User)
+	if err != nil {
+		return update, err
+	}
+
+	update.Title = input.Title
+	update.Body = input.Body
+	if input.Visibility != "" {
+		update.Visibility = input.Visibility
+	}
+
+	return s.repository.UpdateUpdate(ctx, update)
+}
+
+// DeleteCampaignUpdate adalah method dari service buat ngapus kabar terbaru.
+func (s *service) DeleteCampaignUpdate(ctx context.Context, inputID GetCampaignUpdateInput, user user.User) error {
+	update, err := s.findOwnedUpdate(ctx, inputID, user)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteUpdate(ctx, update)
+}
+
+// findOwnedUpdate ngambil kabar terbaru dan mastiin kabarnya milik campaign punya user itu.
+func (s *service) findOwnedUpdate(ctx context.Context, inputID GetCampaignUpdateInput, user user.User) (CampaignUpdate, error) {
+	campaign, err := s.findOwnedCampaign(ctx, inputID.CampaignID, user)
+	if err != nil {
+		return CampaignUpdate{}, err
+	}
+
+	update, err := s.repository.FindUpdateByID(ctx, inputID.ID)
+	if err != nil {
+		return update, err
+	}
+	if update.ID == 0 || update.CampaignID != campaign.ID {
+		return update, ErrUpdateNotFound
+	}
+	return update, nil
+}
+
+// canViewBackersOnly ngecek viewer boleh baca kabar khusus backer: pemilik campaign atau backer yang udah bayar.
+func (s *service) canViewBackersOnly(ctx context.Context, campaign Campaign, viewer *user.User) (bool, error) {
+	if viewer == nil {
+		return false, nil
+	}
+	if viewer.ID == campaign.UserId {
+		return true, nil
+	}
+	return s.repository.IsBacker(ctx, campaign.ID, viewer.ID)
+}
+
+// notifyBackers ngirim notifikasi kabar terbaru ke semua backer campaign.
+// Kalo gagal cuma dicatat aja, kabarnya tetep udah terbit.
+func (s *service) notifyBackers(ctx context.Context, campaign Campaign, update CampaignUpdate) {
+	backerIDs, err := s.repository.FindBackerIDs(ctx, campaign.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil backer campaign", "campaign_id", campaign.ID, "error", err)
+		return
+	}
+
+	recipients := make([]int, 0, len(backerIDs))
+	for _, backerID := range backerIDs {
+		if backerID != campaign.UserId {
+			recipients = append(recipients, backerID)
+		}
+	}
+
+	message := notification.Notification{
+		Type:  notification.TypeCampaignUpdate,
+		Title: fmt.Sprintf("Kabar terbaru dari %s", campaign.Name),
+		Body:  update.Title,
+		Link:  fmt.Sprintf("/campaigns/%d/updates", campaign.ID),
+	}
+	if err := s.notificationService.Notify(ctx, recipients, message); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim notifikasi kabar terbaru", "update_id", update.ID, "error", err)
+	}
+}
+
+// SaveCampaignImage adalah method dari service buat nyimpen gambar campaign yang file-nya udah diunggah.
+// Gambar pertama sebuah campaign otomatis jadi gambar utama.
+func (s *service) SaveCampaignImage(ctx context.Context, inputID GetCampaignDetailInput, input CampaignImageInput, fileLocation string) (CampaignImage, error) {
+	image := CampaignImage{}
+
+	campaign, err := s.findOwnedCampaign(ctx, inputID.ID, input.User)
+	if err != nil {
+		return image, err
+	}
+
+	image.CampaignID = campaign.ID
+	image.FileName = fileLocation
+	if input.IsPrimary || len(campaign.CampaignImages) == 0 {
+		image.IsPrimary = 1
+	}
+
+	return s.repository.SaveImage(ctx, image)
+}
+
+// RecomputeTotals adalah method dari service buat ngitung ulang dana dan jumlah backer campaign dari transaksi yang lunas,
+// misal kalo angkanya sempet nggak sinkron. campaignID 0 artinya semua campaign. Balikin jumlah campaign yang dibenerin.
+func (s *service) RecomputeTotals(ctx context.Context, campaignID int) (int64, error) {
+	if campaignID != 0 {
+		campaign, err := s.repository.FindByID(ctx, campaignID)
+		if err != nil {
+			return 0, err
+		}
+		if campaign.ID == 0 {
+			return 0, ErrCampaignNotFound
+		}
+	}
+
+	return s.repository.RecomputeTotals(ctx, campaignID)
 }
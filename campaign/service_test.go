@@ -0,0 +1,87 @@
+package campaign
+
+import (
+	"campaignku/search"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// updateInput bikin input UpdateCampaign yang isinya sama persis kayak campaign-nya, tinggal diubah yang mau dites.
+func updateInput(f testFixture, campaign Campaign) CreateCampaignInput {
+	return CreateCampaignInput{
+		Name:             campaign.Name,
+		ShortDescription: "Ringkasan baru",
+		Description:      "Deskripsi baru",
+		GoalAmount:       campaign.GoalAmount,
+		StartDate:        campaign.StartDate,
+		EndDate:          campaign.EndDate,
+		User:             f.owner,
+	}
+}
+
+func TestServiceUpdateCampaignStarted(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	s := NewService(f.repository, search.NewMemoryIndex(SearchWeights), nil, nil)
+
+	// Campaign bawaan fixture udah mulai sejam yang lalu.
+	started := f.saveCampaign(t, Campaign{Name: "Sumur Desa", GoalAmount: 1000000})
+	started, err := f.repository.FindByID(ctx, started.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	inputID := GetCampaignDetailInput{ID: started.ID}
+
+	input := updateInput(f, started)
+	input.GoalAmount = 2000000
+	if _, err := s.UpdateCampaign(ctx, inputID, input); !errors.Is(err, ErrCampaignStarted) {
+		t.Errorf("ganti target dana setelah mulai = %v, mau %v", err, ErrCampaignStarted)
+	}
+
+	input = updateInput(f, started)
+	input.StartDate = time.Now().Add(time.Hour)
+	if _, err := s.UpdateCampaign(ctx, inputID, input); !errors.Is(err, ErrCampaignStarted) {
+		t.Errorf("ganti tanggal mulai setelah mulai = %v, mau %v", err, ErrCampaignStarted)
+	}
+
+	// Kolom lain tetep boleh diubah selama target dana dan tanggal mulainya sama.
+	input = updateInput(f, started)
+	input.Name = "Sumur Desa Baru"
+	updated, err := s.UpdateCampaign(ctx, inputID, input)
+	if err != nil {
+		t.Fatalf("UpdateCampaign tanpa ganti target dana: %v", err)
+	}
+	if updated.Name != "Sumur Desa Baru" || updated.GoalAmount != 1000000 {
+		t.Errorf("UpdateCampaign = (%q, %d), mau (%q, %d)", updated.Name, updated.GoalAmount, "Sumur Desa Baru", 1000000)
+	}
+}
+
+func TestServiceUpdateCampaignStartDate(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	s := NewService(f.repository, search.NewMemoryIndex(SearchWeights), nil, nil)
+
+	now := time.Now()
+	upcoming := f.saveCampaign(t, Campaign{Name: "Perpustakaan Keliling", GoalAmount: 1000000, StartDate: now.AddDate(0, 0, 1)})
+	inputID := GetCampaignDetailInput{ID: upcoming.ID}
+
+	input := updateInput(f, upcoming)
+	input.StartDate = now.Add(-time.Hour)
+	if _, err := s.UpdateCampaign(ctx, inputID, input); !errors.Is(err, ErrInvalidStartDate) {
+		t.Errorf("tanggal mulai di masa lalu = %v, mau %v", err, ErrInvalidStartDate)
+	}
+
+	// Sebelum mulai, target dana dan tanggal mulai masih boleh diganti.
+	input = updateInput(f, upcoming)
+	input.StartDate = now.AddDate(0, 0, 2)
+	input.GoalAmount = 3000000
+	updated, err := s.UpdateCampaign(ctx, inputID, input)
+	if err != nil {
+		t.Fatalf("UpdateCampaign sebelum mulai: %v", err)
+	}
+	if updated.GoalAmount != 3000000 || updated.StartDate.Sub(input.StartDate).Abs() > time.Second {
+		t.Errorf("UpdateCampaign = (%d, %v), mau (%d, %v)", updated.GoalAmount, updated.StartDate, 3000000, input.StartDate)
+	}
+}
@@ -0,0 +1,179 @@
+package campaign
+
+import (
+	"campaignku/helper"
+	"campaignku/tracing"
+	"campaignku/user"
+	"context"
+	"time"
+)
+
+// tracedService ngebungkus Service biar tiap pemanggilannya jadi satu span, lengkap sama error-nya.
+type tracedService struct {
+	next Service
+}
+
+// NewTracedService bungkus service campaign biar tiap pemanggilannya kecatat di trace.
+func NewTracedService(next Service) *tracedService {
+	return &tracedService{next}
+}
+
+// GetCampaigns nge-trace GetCampaigns dari service aslinya.
+func (s *tracedService) GetCampaigns(ctx context.Context, input GetCampaignsInput) (result []Campaign, pagination helper.Pagination, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.GetCampaigns")
+	defer func() { end(err) }()
+
+	return s.next.GetCampaigns(ctx, input)
+}
+
+// CreateCampaign nge-trace CreateCampaign dari service aslinya.
+func (s *tracedService) CreateCampaign(ctx context.Context, input CreateCampaignInput) (result Campaign, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.CreateCampaign")
+	defer func() { end(err) }()
+
+	return s.next.CreateCampaign(ctx, input)
+}
+
+// UpdateCampaign nge-trace UpdateCampaign dari service aslinya.
+func (s *tracedService) UpdateCampaign(ctx context.Context, inputID GetCampaignDetailInput, input CreateCampaignInput) (result Campaign, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.UpdateCampaign")
+	defer func() { end(err) }()
+
+	return s.next.UpdateCampaign(ctx, inputID, input)
+}
+
+// SearchCampaigns nge-trace SearchCampaigns dari service aslinya.
+func (s *tracedService) SearchCampaigns(ctx context.Context, input SearchCampaignsInput) (result []SearchResult, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.SearchCampaigns")
+	defer func() { end(err) }()
+
+	return s.next.SearchCampaigns(ctx, input)
+}
+
+// RebuildSearchIndex nge-trace RebuildSearchIndex dari service aslinya.
+func (s *tracedService) RebuildSearchIndex(ctx context.Context) (err error) {
+	ctx, end := tracing.Start(ctx, "campaign.RebuildSearchIndex")
+	defer func() { end(err) }()
+
+	return s.next.RebuildSearchIndex(ctx)
+}
+
+// GetCampaignByID nge-trace GetCampaignByID dari service aslinya.
+func (s *tracedService) GetCampaignByID(ctx context.Context, input GetCampaignDetailInput) (result Campaign, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.GetCampaignByID")
+	defer func() { end(err) }()
+
+	return s.next.GetCampaignByID(ctx, input)
+}
+
+// GetCategories nge-trace GetCategories dari service aslinya.
+func (s *tracedService) GetCategories(ctx context.Context) (result []CategoryWithCount, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.GetCategories")
+	defer func() { end(err) }()
+
+	return s.next.GetCategories(ctx)
+}
+
+// CreateCategory nge-trace CreateCategory dari service aslinya.
+func (s *tracedService) CreateCategory(ctx context.Context, input CategoryInput) (result Category, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.CreateCategory")
+	defer func() { end(err) }()
+
+	return s.next.CreateCategory(ctx, input)
+}
+
+// UpdateCategory nge-trace UpdateCategory dari service aslinya.
+func (s *tracedService) UpdateCategory(ctx context.Context, inputID GetCategoryInput, input CategoryInput) (result Category, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.UpdateCategory")
+	defer func() { end(err) }()
+
+	return s.next.UpdateCategory(ctx, inputID, input)
+}
+
+// DeleteCategory nge-trace DeleteCategory dari service aslinya.
+func (s *tracedService) DeleteCategory(ctx context.Context, inputID GetCategoryInput) (err error) {
+	ctx, end := tracing.Start(ctx, "campaign.DeleteCategory")
+	defer func() { end(err) }()
+
+	return s.next.DeleteCategory(ctx, inputID)
+}
+
+// CloseExpiredCampaigns nge-trace CloseExpiredCampaigns dari service aslinya.
+func (s *tracedService) CloseExpiredCampaigns(ctx context.Context, now time.Time) (result []Campaign, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.CloseExpiredCampaigns")
+	defer func() { end(err) }()
+
+	return s.next.CloseExpiredCampaigns(ctx, now)
+}
+
+// CreateReward nge-trace CreateReward dari service aslinya.
+func (s *tracedService) CreateReward(ctx context.Context, inputID GetCampaignDetailInput, input RewardInput) (result Reward, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.CreateReward")
+	defer func() { end(err) }()
+
+	return s.next.CreateReward(ctx, inputID, input)
+}
+
+// UpdateReward nge-trace UpdateReward dari service aslinya.
+func (s *tracedService) UpdateReward(ctx context.Context, inputID GetRewardInput, input RewardInput) (result Reward, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.UpdateReward")
+	defer func() { end(err) }()
+
+	return s.next.UpdateReward(ctx, inputID, input)
+}
+
+// DeleteReward nge-trace DeleteReward dari service aslinya.
+func (s *tracedService) DeleteReward(ctx context.Context, inputID GetRewardInput, user user.User) (err error) {
+	ctx, end := tracing.Start(ctx, "campaign.DeleteReward")
+	defer func() { end(err) }()
+
+	return s.next.DeleteReward(ctx, inputID, user)
+}
+
+// GetCampaignUpdates nge-trace GetCampaignUpdates dari service aslinya.
+func (s *tracedService) GetCampaignUpdates(ctx context.Context, inputID GetCampaignDetailInput, viewer *user.User) (result []CampaignUpdate, allowed bool, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.GetCampaignUpdates")
+	defer func() { end(err) }()
+
+	return s.next.GetCampaignUpdates(ctx, inputID, viewer)
+}
+
+// CreateCampaignUpdate nge-trace CreateCampaignUpdate dari service aslinya.
+func (s *tracedService) CreateCampaignUpdate(ctx context.Context, inputID GetCampaignDetailInput, input CampaignUpdateInput) (result CampaignUpdate, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.CreateCampaignUpdate")
+	defer func() { end(err) }()
+
+	return s.next.CreateCampaignUpdate(ctx, inputID, input)
+}
+
+// UpdateCampaignUpdate nge-trace UpdateCampaignUpdate dari service aslinya.
+func (s *tracedService) UpdateCampaignUpdate(ctx context.Context, inputID GetCampaignUpdateInput, input CampaignUpdateInput) (result CampaignUpdate, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.UpdateCampaignUpdate")
+	defer func() { end(err) }()
+
+	return s.next.UpdateCampaignUpdate(ctx, inputID, input)
+}
+
+// DeleteCampaignUpdate nge-trace DeleteCampaignUpdate dari service aslinya.
+func (s *tracedService) DeleteCampaignUpdate(ctx context.Context, inputID GetCampaignUpdateInput, user user.User) (err error) {
+	ctx, end := tracing.Start(ctx, "campaign.DeleteCampaignUpdate")
+	defer func() { end(err) }()
+
+	return s.next.DeleteCampaignUpdate(ctx, inputID, user)
+}
+
+// SaveCampaignImage nge-trace SaveCampaignImage dari service aslinya.
+func (s *tracedService) SaveCampaignImage(ctx context.Context, inputID GetCampaignDetailInput, input CampaignImageInput, fileLocation string) (result CampaignImage, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.SaveCampaignImage")
+	defer func() { end(err) }()
+
+	return s.next.SaveCampaignImage(ctx, inputID, input, fileLocation)
+}
+
+// RecomputeTotals nge-trace RecomputeTotals dari service aslinya.
+func (s *tracedService) RecomputeTotals(ctx context.Context, campaignID int) (result int64, err error) {
+	ctx, end := tracing.Start(ctx, "campaign.RecomputeTotals")
+	defer func() { end(err) }()
+
+	return s.next.RecomputeTotals(ctx, campaignID)
+}
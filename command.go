@@ -0,0 +1,505 @@
+package main
+
+import (
+	"bufio"
+	"campaignku/campaign"
+	"campaignku/migration"
+	"campaignku/transaction"
+	"campaignku/user"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/term"
+	"gorm.io/gorm"
+)
+
+// Cara pakai perintah command line.
+const usage = `Cara pakai:
+  campaignku                                                       jalanin web server
+  campaignku migrate up                                            terapkan semua migrasi yang belum diterapkan
+  campaignku migrate down [jumlah]                                 batalkan migrasi terakhir (default 1)
+  campaignku migrate status                                        tampilkan status semua migrasi
+  campaignku user create-admin -name NAMA -email EMAIL            bikin akun admin baru
+  campaignku user reset-password -email EMAIL                      ganti password pengguna
+  campaignku user set-role -email EMAIL -role user|admin           ganti role pengguna
+  campaignku campaign recompute-totals [-id ID]                    hitung ulang dana dan backer dari transaksi lunas
+  campaignku payment reprocess-stuck [-older-than 1h]              cek ulang status transaksi pending ke Midtrans
+  campaignku seed                                                  isi database dengan data demo
+
+Password buat create-admin dan reset-password ditanyain tanpa ditampilin, atau dibaca dari stdin
+kalo stdin-nya bukan terminal. Bisa juga lewat environment variable CAMPAIGNKU_PASSWORD, yang juga
+dipake seed sebagai password semua pengguna demo (default "password").`
+
+// passwordEnv adalah environment variable buat ngasih password ke perintah command line, misal dari script.
+// Password sengaja nggak bisa dikasih lewat flag, biar nggak keliatan di ps dan nggak kecatat di history shell.
+const passwordEnv = "CAMPAIGNKU_PASSWORD"
+
+// commands nyimpen semua yang dibutuhin perintah command line. Perintahnya sengaja lewat service yang sama
+// kaya API, biar aturan bisnisnya (hash password, validasi, index pencarian, event) tetep kepake.
+type commands struct {
+	userService        user.Service
+	campaignService    campaign.Service
+	transactionService transaction.Service
+	imageDir           string // Folder gambar, dipake seed buat nyimpen gambar campaign demo.
+}
+
+// Fungsi buat jalanin perintah command line selain web server.
+func (c commands) run(ctx context.Context, args []string) error {
+	switch args[0] {
+	case "user":
+		return c.runUser(ctx, args[1:])
+	case "campaign":
+		return c.runCampaign(ctx, args[1:])
+	case "payment":
+		return c.runPayment(ctx, args[1:])
+	case "seed":
+		return c.runSeed(ctx, args[1:])
+	default:
+		return fmt.Errorf("perintah %q tidak dikenal\n%s", args[0], usage)
+	}
+}
+
+// Fungsi buat jalanin perintah migrate up, down, atau status.
+func runMigrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		migrations, err := migrator.Up()
+		for _, applied := range migrations {
+			fmt.Printf("diterapkan  %04d_%s\n", applied.Version, applied.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(migrations) == 0 {
+			fmt.Println("skema sudah paling baru")
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("jumlah migrasi yang dibatalkan harus angka positif, bukan %q", args[1])
+			}
+		}
+
+		migrations, err := migrator.Down(steps)
+		for _, rolledBack := range migrations {
+			fmt.Printf("dibatalkan  %04d_%s\n", rolledBack.Version, rolledBack.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSI\tNAMA\tDITERAPKAN")
+		for _, status := range statuses {
+			appliedAt := "belum"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(writer, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return writer.Flush()
+
+	default:
+		return fmt.Errorf("perintah migrate %q tidak dikenal\n%s", args[0], usage)
+	}
+}
+
+// Fungsi buat jalanin perintah user create-admin, reset-password, atau set-role.
+func (c commands) runUser(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	flags := flag.NewFlagSet("user "+args[0], flag.ContinueOnError)
+	name := flags.String("name", "", "nama pengguna")
+	email := flags.String("email", "", "email pengguna")
+	role := flags.String("role", "", "role baru (user atau admin)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "create-admin":
+		if err := requireFlags(flags, "name", "email"); err != nil {
+			return err
+		}
+
+		_, err := c.userService.GetUserByEmail(ctx, *email)
+		if err == nil {
+			return fmt.Errorf("email %s sudah terdaftar, pakai \"user set-role\" buat ngangkat jadi admin", *email)
+		}
+		if !errors.Is(err, user.ErrUserNotFound) {
+			return err
+		}
+
+		password, err := readPassword("Password admin: ")
+		if err != nil {
+			return err
+		}
+
+		newUser, err := c.userService.RegisterUser(ctx, user.RegisterUserInput{
+			Name:       *name,
+			Occupation: "Admin",
+			Email:      *email,
+			Password:   password,
+		})
+		if err != nil {
+			return err
+		}
+
+		admin, err := c.userService.SetRole(ctx, newUser.ID, user.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("admin %s (ID %d) berhasil dibuat\n", admin.Email, admin.ID)
+		return nil
+
+	case "reset-password":
+		if err := requireFlags(flags, "email"); err != nil {
+			return err
+		}
+
+		existingUser, err := c.userService.GetUserByEmail(ctx, *email)
+		if err != nil {
+			return err
+		}
+
+		password, err := readPassword("Password baru: ")
+		if err != nil {
+			return err
+		}
+
+		_, err = c.userService.SetPassword(ctx, existingUser.ID, password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("password %s berhasil diganti\n", existingUser.Email)
+		return nil
+
+	case "set-role":
+		if err := requireFlags(flags, "email", "role"); err != nil {
+			return err
+		}
+
+		existingUser, err := c.userService.GetUserByEmail(ctx, *email)
+		if err != nil {
+			return err
+		}
+
+		updatedUser, err := c.userService.SetRole(ctx, existingUser.ID, *role)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("role %s sekarang %s\n", updatedUser.Email, updatedUser.Role)
+		return nil
+
+	default:
+		return fmt.Errorf("perintah user %q tidak dikenal\n%s", args[0], usage)
+	}
+}
+
+// Fungsi buat jalanin perintah campaign recompute-totals.
+func (c commands) runCampaign(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "recompute-totals" {
+		return errors.New(usage)
+	}
+
+	flags := flag.NewFlagSet("campaign recompute-totals", flag.ContinueOnError)
+	campaignID := flags.Int("id", 0, "ID campaign, kosong artinya semua campaign")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	fixed, err := c.campaignService.RecomputeTotals(ctx, *campaignID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d campaign angkanya dibenerin\n", fixed)
+	return nil
+}
+
+// Fungsi buat jalanin perintah payment reprocess-stuck.
+func (c commands) runPayment(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "reprocess-stuck" {
+		return errors.New(usage)
+	}
+
+	flags := flag.NewFlagSet("payment reprocess-stuck", flag.ContinueOnError)
+	olderThan := flags.Duration("older-than", time.Hour, "umur minimal transaksi pending yang dicek ulang")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	transactions, err := c.transactionService.ReprocessStuckPayments(ctx, time.Now().Add(-*olderThan))
+	for _, changed := range transactions {
+		fmt.Printf("%s  %s\n", changed.Code, changed.Status)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d transaksi statusnya berubah\n", len(transactions))
+	return nil
+}
+
+// demoCampaign adalah satu campaign contoh buat perintah seed.
+type demoCampaign struct {
+	Name             string
+	ShortDescription string
+	Description      string
+	GoalAmount       int
+	Tags             []string
+	RewardTitle      string
+	RewardAmount     int
+	Color            color.RGBA // Warna gambar campaign yang digenerate.
+}
+
+// demoUser adalah satu pengguna contoh buat perintah seed, lengkap sama campaign-nya.
+type demoUser struct {
+	Name       string
+	Email      string
+	Occupation string
+	Campaign   demoCampaign
+}
+
+// Data demo yang diisi sama perintah seed.
+var demoUsers = []demoUser{
+	{
+		Name:       "Sari Wulandari",
+		Email:      "sari@demo.campaignku.id",
+		Occupation: "Petani kopi",
+		Campaign: demoCampaign{
+			Name:             "Kopi Gayo Langsung dari Kebun",
+			ShortDescription: "Bantu petani Gayo punya mesin roasting sendiri",
+			Description:      "Selama ini kopi kami dijual mentah ke tengkulak. Dengan mesin roasting sendiri, petani bisa jual kopi sangrai dengan harga yang lebih adil.",
+			GoalAmount:       50000000,
+			Tags:             []string{"kopi", "umkm"},
+			RewardTitle:      "Kopi sangrai 250 gram",
+			RewardAmount:     100000,
+			Color:            color.RGBA{R: 111, G: 78, B: 55, A: 255},
+		},
+	},
+	{
+		Name:       "Budi Santoso",
+		Email:      "budi@demo.campaignku.id",
+		Occupation: "Guru",
+		Campaign: demoCampaign{
+			Name:             "Perpustakaan Keliling Desa",
+			ShortDescription: "Motor perpustakaan buat anak-anak di desa terpencil",
+			Description:      "Kami mau bikin perpustakaan keliling pakai motor yang datang ke lima desa tiap minggu, lengkap dengan buku cerita dan buku pelajaran.",
+			GoalAmount:       30000000,
+			Tags:             []string{"pendidikan", "anak"},
+			RewardTitle:      "Kartu pos ucapan terima kasih",
+			RewardAmount:     50000,
+			Color:            color.RGBA{R: 46, G: 134, B: 193, A: 255},
+		},
+	},
+	{
+		Name:       "Maya Putri",
+		Email:      "maya@demo.campaignku.id",
+		Occupation: "Desainer",
+		Campaign: demoCampaign{
+			Name:             "Tas Daur Ulang dari Banner Bekas",
+			ShortDescription: "Ubah banner bekas jadi tas yang awet dan keren",
+			Description:      "Banner bekas acara biasanya langsung dibuang. Kami olah jadi tas tahan air sambil memberdayakan penjahit rumahan.",
+			GoalAmount:       20000000,
+			Tags:             []string{"lingkungan", "fashion"},
+			RewardTitle:      "Satu tas daur ulang",
+			RewardAmount:     150000,
+			Color:            color.RGBA{R: 39, G: 174, B: 96, A: 255},
+		},
+	},
+}
+
+// Fungsi buat jalanin perintah seed. Pengguna demo yang emailnya udah ada dilewatin,
+// jadi perintahnya aman dijalanin berkali-kali. Password semua pengguna demo diambil dari CAMPAIGNKU_PASSWORD.
+func (c commands) runSeed(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	password := os.Getenv(passwordEnv)
+	if password == "" {
+		password = "password"
+	}
+
+	if err := os.MkdirAll(c.imageDir, 0o755); err != nil {
+		return err
+	}
+
+	for _, demo := range demoUsers {
+		_, err := c.userService.GetUserByEmail(ctx, demo.Email)
+		if err == nil {
+			fmt.Printf("dilewati   %s (sudah ada)\n", demo.Email)
+			continue
+		}
+		if !errors.Is(err, user.ErrUserNotFound) {
+			return err
+		}
+
+		newUser, err := c.userService.RegisterUser(ctx, user.RegisterUserInput{
+			Name:       demo.Name,
+			Occupation: demo.Occupation,
+			Email:      demo.Email,
+			Password:   password,
+		})
+		if err != nil {
+			return err
+		}
+
+		newCampaign, err := c.seedCampaign(ctx, newUser, demo.Campaign)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("dibuat     %s dengan campaign %q (ID %d)\n", newUser.Email, newCampaign.Name, newCampaign.ID)
+	}
+
+	return nil
+}
+
+// Fungsi buat bikin satu campaign demo lengkap sama reward dan gambarnya.
+func (c commands) seedCampaign(ctx context.Context, owner user.User, demo demoCampaign) (campaign.Campaign, error) {
+	now := time.Now()
+	newCampaign, err := c.campaignService.CreateCampaign(ctx, campaign.CreateCampaignInput{
+		Name:             demo.Name,
+		ShortDescription: demo.ShortDescription,
+		Description:      demo.Description,
+		GoalAmount:       demo.GoalAmount,
+		Tags:             demo.Tags,
+		StartDate:        now,
+		EndDate:          now.AddDate(0, 0, 30),
+		User:             owner,
+	})
+	if err != nil {
+		return newCampaign, err
+	}
+
+	inputID := campaign.GetCampaignDetailInput{ID: newCampaign.ID}
+
+	_, err = c.campaignService.CreateReward(ctx, inputID, campaign.RewardInput{
+		Title:         demo.RewardTitle,
+		MinimumAmount: demo.RewardAmount,
+		User:          owner,
+	})
+	if err != nil {
+		return newCampaign, err
+	}
+
+	path := filepath.Join(c.imageDir, fmt.Sprintf("campaign-%d-seed.png", newCampaign.ID))
+	if err := writeDemoImage(path, demo.Color); err != nil {
+		return newCampaign, err
+	}
+
+	_, err = c.campaignService.SaveCampaignImage(ctx, inputID, campaign.CampaignImageInput{IsPrimary: true, User: owner}, path)
+	if err != nil {
+		return newCampaign, err
+	}
+
+	return newCampaign, nil
+}
+
+// Fungsi buat bikin gambar PNG polos dengan gradasi tipis dari warna yang dikasih.
+func writeDemoImage(path string, base color.RGBA) error {
+	const width, height = 800, 450
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		shade := uint8(y * 60 / height)
+		for x := 0; x < width; x++ {
+			canvas.SetRGBA(x, y, color.RGBA{
+				R: darken(base.R, shade),
+				G: darken(base.G, shade),
+				B: darken(base.B, shade),
+				A: 255,
+			})
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(file, canvas); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Fungsi buat ngurangin nilai satu kanal warna tanpa lewat dari nol.
+func darken(value, amount uint8) uint8 {
+	if value < amount {
+		return 0
+	}
+	return value - amount
+}
+
+// Fungsi buat mastiin flag yang wajib udah diisi.
+func requireFlags(flags *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if flags.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("flag -%s wajib diisi\n%s", name, usage)
+		}
+	}
+	return nil
+}
+
+// Fungsi buat dapetin password perintah command line. Diambil dari CAMPAIGNKU_PASSWORD kalo diisi.
+// Kalo nggak, ditanyain di terminal tanpa ditampilin, atau dibaca satu baris dari stdin kalo stdin-nya bukan terminal.
+func readPassword(prompt string) (string, error) {
+	if password := os.Getenv(passwordEnv); password != "" {
+		return password, nil
+	}
+
+	var password string
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(os.Stderr, prompt)
+		input, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		password = string(input)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if password == "" {
+		return "", errors.New("password wajib diisi")
+	}
+	return password, nil
+}
@@ -0,0 +1,38 @@
+// Package comment menyediakan komentar dan diskusi di halaman campaign.
+package comment
+
+import (
+	"campaignku/user"
+	"time"
+)
+
+// Comment adalah satu komentar di campaign. Balasan cuma boleh satu tingkat, jadi ParentID selalu nunjuk ke komentar utama.
+type Comment struct {
+	ID         int
+	CampaignID int
+	UserID     int
+	ParentID   *int
+	Body       string
+	IsHidden   bool // Disembunyiin pemilik campaign, isinya cuma keliatan sama pemilik campaign dan penulisnya.
+	IsPinned   bool // Disematin pemilik campaign di paling atas. Cuma komentar utama yang bisa disematin.
+	EditedAt   *time.Time
+	DeletedAt  *time.Time // Dihapus penulisnya. Barisnya tetep ada biar balasannya nggak kehilangan induk.
+	CreatedAt  time.Time
+	UpdateAt   time.Time `gorm:"column:updated_at"`
+	User       user.User
+	Replies    []Comment `gorm:"foreignKey:ParentID"`
+	IsCreator  bool      `gorm:"-"` // Ditulis pemilik campaign, diisi sama service.
+}
+
+// IsDeleted ngecek komentar udah dihapus apa belum.
+func (c Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// CommentRevision adalah isi lama sebuah komentar sebelum diubah penulisnya.
+type CommentRevision struct {
+	ID        int
+	CommentID int
+	Body      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,97 @@
+package comment
+
+import "time"
+
+// CommentFormatter adalah struktur data buat satu komentar beserta balasannya.
+// Isi komentar yang dihapus dikosongin. Isi komentar yang disembunyiin juga dikosongin,
+// kecuali yang baca pemilik campaign atau penulisnya sendiri.
+type CommentFormatter struct {
+	ID        int                  `json:"id"`
+	ParentID  *int                 `json:"parent_id"`
+	Body      string               `json:"body"`
+	User      CommentUserFormatter `json:"user"`
+	IsCreator bool                 `json:"is_creator"`
+	IsHidden  bool                 `json:"is_hidden"`
+	IsPinned  bool                 `json:"is_pinned"`
+	IsDeleted bool                 `json:"is_deleted"`
+	IsEdited  bool                 `json:"is_edited"`
+	EditedAt  *time.Time           `json:"edited_at"`
+	CreatedAt time.Time            `json:"created_at"`
+	Replies   []CommentFormatter   `json:"replies,omitempty"`
+}
+
+// CommentUserFormatter adalah data penulis komentar yang boleh ditampilin ke publik.
+type CommentUserFormatter struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ImageURL string `json:"image_url"`
+}
+
+// FormatComment mengonversi data komentar menjadi CommentFormatter.
+// viewerID bernilai 0 kalo yang baca belum login, canModerate bernilai true kalo yang baca pemilik campaign.
+func FormatComment(comment Comment, viewerID int, canModerate bool) CommentFormatter {
+	formatter := CommentFormatter{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		Body:      comment.Body,
+		IsCreator: comment.IsCreator,
+		IsHidden:  comment.IsHidden,
+		IsPinned:  comment.IsPinned,
+		IsDeleted: comment.IsDeleted(),
+		IsEdited:  comment.EditedAt != nil,
+		EditedAt:  comment.EditedAt,
+		CreatedAt: comment.CreatedAt,
+	}
+
+	if comment.IsDeleted() {
+		formatter.Body = ""
+	} else {
+		formatter.User = CommentUserFormatter{
+			ID:       comment.User.ID,
+			Name:     comment.User.Name,
+			ImageURL: comment.User.AvatarFileName,
+		}
+	}
+
+	if comment.IsHidden && !canModerate && comment.UserID != viewerID {
+		formatter.Body = ""
+	}
+
+	for _, reply := range comment.Replies {
+		formatter.Replies = append(formatter.Replies, FormatComment(reply, viewerID, canModerate))
+	}
+
+	return formatter
+}
+
+// FormatComments mengonversi daftar komentar menjadi daftar CommentFormatter.
+func FormatComments(comments []Comment, viewerID int, canModerate bool) []CommentFormatter {
+	commentsFormatter := []CommentFormatter{}
+
+	for _, comment := range comments {
+		commentsFormatter = append(commentsFormatter, FormatComment(comment, viewerID, canModerate))
+	}
+
+	return commentsFormatter
+}
+
+// CommentRevisionFormatter adalah struktur data buat satu isi lama komentar.
+type CommentRevisionFormatter struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FormatCommentRevisions mengonversi riwayat perubahan komentar menjadi daftar CommentRevisionFormatter.
+func FormatCommentRevisions(revisions []CommentRevision) []CommentRevisionFormatter {
+	revisionsFormatter := []CommentRevisionFormatter{}
+
+	for _, revision := range revisions {
+		revisionFormatter := CommentRevisionFormatter{
+			Body:      revision.Body,
+			CreatedAt: revision.CreatedAt,
+		}
+		revisionsFormatter = append(revisionsFormatter, revisionFormatter)
+	}
+
+	return revisionsFormatter
+}
@@ -0,0 +1,36 @@
+package comment
+
+import "campaignku/user"
+
+// GetCommentsInput adalah struktur data buat nampung query string paginasi saat minta daftar komentar.
+type GetCommentsInput struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// GetCommentInput adalah struktur data buat nampung ID campaign dan ID komentar dari URI.
+type GetCommentInput struct {
+	CampaignID int `uri:"id" binding:"required"`
+	ID         int `uri:"comment_id" binding:"required"`
+}
+
+// CreateCommentInput adalah struktur data yang digunakan sebagai input saat nulis komentar atau balasan.
+type CreateCommentInput struct {
+	Body     string    `json:"body" binding:"required,max=2000"`
+	ParentID *int      `json:"parent_id"` // Diisi kalo komentarnya balasan.
+	User     user.User // Diisi dari user yang lagi login, bukan dari JSON.
+}
+
+// UpdateCommentInput adalah struktur data yang digunakan sebagai input saat penulis ngubah komentarnya.
+type UpdateCommentInput struct {
+	Body string    `json:"body" binding:"required,max=2000"`
+	User user.User // Diisi dari user yang lagi login, bukan dari JSON.
+}
+
+// ModerateCommentInput adalah struktur data yang digunakan sebagai input saat pemilik campaign nyembunyiin atau nyematin komentar.
+// Field yang nggak dikirim nggak diubah.
+type ModerateCommentInput struct {
+	Hidden *bool     `json:"hidden"`
+	Pinned *bool     `json:"pinned"`
+	User   user.User // Diisi dari user yang lagi login, bukan dari JSON.
+}
@@ -0,0 +1,132 @@
+package comment
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository adalah interface untuk fungsi-fungsi database yang berkaitan dengan komentar.
+type Repository interface {
+	FindByCampaignID(ctx context.Context, campaignID int, page int, limit int) ([]Comment, int64, error) // Fungsi untuk dapetin komentar utama plus balasannya, per halaman.
+	FindByID(ctx context.Context, ID int) (Comment, error)                                               // Fungsi untuk dapetin satu komentar berdasarkan ID.
+	Save(ctx context.Context, comment Comment) (Comment, error)                                          // Fungsi untuk nyimpen komentar baru.
+	Update(ctx context.Context, comment Comment) (Comment, error)                                        // Fungsi untuk nyimpen perubahan status komentar.
+	Edit(ctx context.Context, comment Comment, revision CommentRevision) (Comment, error)                // Fungsi untuk nyimpen isi baru komentar sekaligus isi lamanya.
+	FindRevisions(ctx context.Context, commentID int) ([]CommentRevision, error)                         // Fungsi untuk dapetin riwayat perubahan komentar, yang paling baru duluan.
+}
+
+// repository adalah implementasi dari Repository, pakai GORM.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository adalah fungsi pembuat repository baru.
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+// FindByCampaignID adalah method dari repository untuk dapetin komentar utama sebuah campaign per halaman.
+// Komentar yang disematin ada di paling atas, sisanya yang paling baru duluan. Balasannya diurutin dari yang paling lama.
+// Komentar utama yang udah dihapus cuma ikut kalo masih punya balasan, biar diskusinya tetep nyambung.
+func (r *repository) FindByCampaignID(ctx context.Context, campaignID int, page int, limit int) ([]Comment, int64, error) {
+	var comments []Comment
+	var total int64
+
+	// Query dasarnya dibikin ulang tiap dipake, biar hitungan total nggak nempel ke query ambil data.
+	topLevel := func() *gorm.DB {
+		activeReplies := r.db.WithContext(ctx).Table("comments AS replies").
+			Select("1").
+			Where("replies.parent_id = comments.id AND replies.deleted_at IS NULL")
+		return r.db.WithContext(ctx).Model(&Comment{}).
+			Where("campaign_id = ? AND parent_id IS NULL", campaignID).
+			Where("(deleted_at IS NULL OR EXISTS (?))", activeReplies)
+	}
+
+	err := topLevel().Count(&total).Error
+	if err != nil {
+		return comments, total, err
+	}
+
+	err = topLevel().
+		Order("is_pinned DESC, created_at DESC, id DESC").
+		Offset((page-1)*limit).
+		Limit(limit+1).
+		Preload("User").
+		Preload("Replies", func(db *gorm.DB) *gorm.DB {
+			return db.Where("deleted_at IS NULL").Order("created_at ASC, id ASC")
+		}).
+		Preload("Replies.User").
+		Find(&comments).Error
+	if err != nil {
+		return comments, total, err
+	}
+	return comments, total, nil
+}
+
+// FindByID adalah method dari repository untuk dapetin satu komentar berdasarkan ID, lengkap sama penulisnya.
+func (r *repository) FindByID(ctx context.Context, ID int) (Comment, error) {
+	var comment Comment
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Preload("User").Find(&comment).Error
+	if err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
+
+// Save adalah method dari repository untuk nyimpen komentar baru.
+func (r *repository) Save(ctx context.Context, comment Comment) (Comment, error) {
+	now := time.Now()
+	comment.CreatedAt = now
+	comment.UpdateAt = now
+
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Create(&comment).Error
+	if err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
+
+// Update adalah method dari repository untuk nyimpen perubahan status komentar, misal disembunyiin, disematin, atau dihapus.
+func (r *repository) Update(ctx context.Context, comment Comment) (Comment, error) {
+	comment.UpdateAt = time.Now()
+
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Save(&comment).Error
+	if err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
+
+// Edit adalah method dari repository untuk nyimpen isi baru komentar dan isi lamanya dalam satu transaksi database.
+func (r *repository) Edit(ctx context.Context, comment Comment, revision CommentRevision) (Comment, error) {
+	now := time.Now()
+	comment.UpdateAt = now
+	comment.EditedAt = &now
+	revision.CreatedAt = now
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Save(&comment).Error
+	})
+	if err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
+
+// FindRevisions adalah method dari repository untuk dapetin riwayat perubahan komentar, yang paling baru duluan.
+func (r *repository) FindRevisions(ctx context.Context, commentID int) ([]CommentRevision, error) {
+	var revisions []CommentRevision
+
+	err := r.db.WithContext(ctx).Where("comment_id = ?", commentID).Order("created_at DESC, id DESC").Find(&revisions).Error
+	if err != nil {
+		return revisions, err
+	}
+	return revisions, nil
+}
@@ -0,0 +1,269 @@
+package comment
+
+import (
+	"campaignku/campaign"
+	"campaignku/helper"
+	"campaignku/notification"
+	"campaignku/user"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Batas default jumlah komentar utama per halaman.
+const defaultLimit = 20
+
+// Error yang bisa dibalikin sama service komentar.
+var (
+	ErrCommentNotFound = errors.New("komentar tidak ditemukan")
+	ErrNotAuthor       = errors.New("bukan penulis komentar")
+	ErrInvalidParent   = errors.New("balasan hanya bisa ditujukan ke komentar utama di campaign yang sama")
+	ErrPinReply        = errors.New("balasan tidak bisa disematkan")
+)
+
+// Service adalah interface yang mendefinisikan fungsi yang harus ada di service komentar.
+type Service interface {
+	GetComments(ctx context.Context, inputID campaign.GetCampaignDetailInput, input GetCommentsInput, viewer *user.User) ([]Comment, helper.Pagination, bool, error) // Fungsi buat dapetin komentar per halaman, plus boleh nggaknya viewer moderasi.
+	CreateComment(ctx context.Context, inputID campaign.GetCampaignDetailInput, input CreateCommentInput) (Comment, error)                                           // Fungsi buat nulis komentar atau balasan.
+	UpdateComment(ctx context.Context, inputID GetCommentInput, input UpdateCommentInput) (Comment, error)                                                           // Fungsi buat ngubah komentar, cuma boleh sama penulisnya.
+	DeleteComment(ctx context.Context, inputID GetCommentInput, user user.User) error                                                                                // Fungsi buat ngapus komentar, cuma boleh sama penulisnya.
+	ModerateComment(ctx context.Context, inputID GetCommentInput, input ModerateCommentInput) (Comment, error)                                                       // Fungsi buat nyembunyiin atau nyematin komentar, cuma boleh sama pemilik campaign.
+	GetCommentHistory(ctx context.Context, inputID GetCommentInput, viewer *user.User) ([]CommentRevision, error)                                                    // Fungsi buat dapetin riwayat perubahan komentar.
+}
+
+// service adalah struct yang implementasi dari Service.
+type service struct {
+	repository          Repository
+	campaignRepository  campaign.Repository
+	notificationService notification.Service // Buat ngabarin penulis komentar kalo ada yang bales.
+}
+
+// NewService adalah fungsi pembuat service baru.
+func NewService(repository Repository, campaignRepository campaign.Repository, notificationService notification.Service) *service {
+	return &service{repository, campaignRepository, notificationService}
+}
+
+// GetComments adalah method dari service buat dapetin komentar utama sebuah campaign per halaman, lengkap sama balasannya.
+// Selain daftarnya, method ini juga balikin apakah viewer pemilik campaign (boleh liat komentar yang disembunyiin).
+// Viewer boleh nil kalo yang buka belum login.
+func (s *service) GetComments(ctx context.Context, inputID campaign.GetCampaignDetailInput, input GetCommentsInput, viewer *user.User) ([]Comment, helper.Pagination, bool, error) {
+	if input.Limit == 0 {
+		input.Limit = defaultLimit
+	}
+	if input.Page == 0 {
+		input.Page = 1
+	}
+
+	pagination := helper.Pagination{Page: input.Page, Limit: input.Limit}
+
+	targetCampaign, err := s.findCampaign(ctx, inputID.ID)
+	if err != nil {
+		return nil, pagination, false, err
+	}
+
+	comments, total, err := s.repository.FindByCampaignID(ctx, targetCampaign.ID, input.Page, input.Limit)
+	if err != nil {
+		return nil, pagination, false, err
+	}
+	pagination.Total = total
+
+	// Repository ngambil satu data lebih, jadi kalo kelebihan berarti masih ada halaman berikutnya.
+	if len(comments) > input.Limit {
+		comments = comments[:input.Limit]
+		pagination.HasMore = true
+	}
+
+	for i := range comments {
+		markCreator(&comments[i], targetCampaign)
+	}
+
+	canModerate := viewer != nil && viewer.ID == targetCampaign.UserId
+	return comments, pagination, canModerate, nil
+}
+
+// CreateComment adalah method dari service buat nulis komentar atau balasan di campaign.
+func (s *service) CreateComment(ctx context.Context, inputID campaign.GetCampaignDetailInput, input CreateCommentInput) (Comment, error) {
+	comment := Comment{}
+
+	targetCampaign, err := s.findCampaign(ctx, inputID.ID)
+	if err != nil {
+		return comment, err
+	}
+
+	// Balasan cuma boleh ke komentar utama yang masih ada di campaign yang sama.
+	var parent Comment
+	if input.ParentID != nil {
+		parent, err = s.repository.FindByID(ctx, *input.ParentID)
+		if err != nil {
+			return comment, err
+		}
+		if parent.ID == 0 || parent.CampaignID != targetCampaign.ID || parent.ParentID != nil || parent.IsDeleted() {
+			return comment, ErrInvalidParent
+		}
+	}
+
+	comment.CampaignID = targetCampaign.ID
+	comment.UserID = input.User.ID
+	comment.ParentID = input.ParentID
+	comment.Body = input.Body
+
+	newComment, err := s.repository.Save(ctx, comment)
+	if err != nil {
+		return newComment, err
+	}
+
+	newComment.User = input.User
+	markCreator(&newComment, targetCampaign)
+
+	if parent.ID != 0 && parent.UserID != input.User.ID {
+		s.notifyReply(ctx, parent, newComment, targetCampaign)
+	}
+	return newComment, nil
+}
+
+// notifyReply ngabarin penulis komentar utama kalo ada yang bales komentarnya.
+// Kalo gagal cuma dicatat aja, balasannya tetep udah kesimpen.
+func (s *service) notifyReply(ctx context.Context, parent Comment, reply Comment, targetCampaign campaign.Campaign) {
+	message := notification.Notification{
+		Type:  notification.TypeCommentReply,
+		Title: fmt.Sprintf("%s membalas komentar kamu", reply.User.Name),
+		Body:  reply.Body,
+		Link:  fmt.Sprintf("/campaigns/%d/comments/%d", targetCampaign.ID, parent.ID),
+	}
+	if err := s.notificationService.Notify(ctx, []int{parent.UserID}, message); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim notifikasi balasan komentar", "comment_id", reply.ID, "error", err)
+	}
+}
+
+// UpdateComment adalah method dari service buat ngubah isi komentar. Isi lamanya disimpen sebagai riwayat.
+func (s *service) UpdateComment(ctx context.Context, inputID GetCommentInput, input UpdateCommentInput) (Comment, error) {
+	comment, targetCampaign, err := s.findComment(ctx, inputID)
+	if err != nil {
+		return comment, err
+	}
+	if comment.UserID != input.User.ID {
+		return comment, ErrNotAuthor
+	}
+
+	if comment.Body == input.Body {
+		markCreator(&comment, targetCampaign)
+		return comment, nil
+	}
+
+	revision := CommentRevision{
+		CommentID: comment.ID,
+		Body:      comment.Body,
+	}
+	comment.Body = input.Body
+
+	updatedComment, err := s.repository.Edit(ctx, comment, revision)
+	if err != nil {
+		return updatedComment, err
+	}
+
+	markCreator(&updatedComment, targetCampaign)
+	return updatedComment, nil
+}
+
+// DeleteComment adalah method dari service buat ngapus komentar. Komentarnya cuma ditandain terhapus, nggak beneran dihapus.
+func (s *service) DeleteComment(ctx context.Context, inputID GetCommentInput, user user.User) error {
+	comment, _, err := s.findComment(ctx, inputID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != user.ID {
+		return ErrNotAuthor
+	}
+
+	now := time.Now()
+	comment.DeletedAt = &now
+	comment.IsPinned = false
+
+	_, err = s.repository.Update(ctx, comment)
+	return err
+}
+
+// ModerateComment adalah method dari service buat nyembunyiin atau nyematin komentar di campaign.
+func (s *service) ModerateComment(ctx context.Context, inputID GetCommentInput, input ModerateCommentInput) (Comment, error) {
+	comment, targetCampaign, err := s.findComment(ctx, inputID)
+	if err != nil {
+		return comment, err
+	}
+	if targetCampaign.UserId != input.User.ID {
+		return comment, campaign.ErrNotOwner
+	}
+
+	if input.Pinned != nil {
+		if *input.Pinned && comment.ParentID != nil {
+			return comment, ErrPinReply
+		}
+		comment.IsPinned = *input.Pinned
+	}
+	if input.Hidden != nil {
+		comment.IsHidden = *input.Hidden
+	}
+
+	updatedComment, err := s.repository.Update(ctx, comment)
+	if err != nil {
+		return updatedComment, err
+	}
+
+	markCreator(&updatedComment, targetCampaign)
+	return updatedComment, nil
+}
+
+// GetCommentHistory adalah method dari service buat dapetin riwayat perubahan komentar.
+// Riwayat komentar yang disembunyiin cuma boleh diliat pemilik campaign dan penulisnya.
+func (s *service) GetCommentHistory(ctx context.Context, inputID GetCommentInput, viewer *user.User) ([]CommentRevision, error) {
+	comment, targetCampaign, err := s.findComment(ctx, inputID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.IsHidden {
+		if viewer == nil || (viewer.ID != comment.UserID && viewer.ID != targetCampaign.UserId) {
+			return nil, ErrCommentNotFound
+		}
+	}
+
+	return s.repository.FindRevisions(ctx, comment.ID)
+}
+
+// findCampaign ngambil campaign dan mastiin campaign-nya ada.
+func (s *service) findCampaign(ctx context.Context, campaignID int) (campaign.Campaign, error) {
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, campaignID)
+	if err != nil {
+		return targetCampaign, err
+	}
+	if targetCampaign.ID == 0 {
+		return targetCampaign, campaign.ErrCampaignNotFound
+	}
+	return targetCampaign, nil
+}
+
+// findComment ngambil komentar yang belum dihapus dan mastiin komentarnya ada di campaign itu.
+func (s *service) findComment(ctx context.Context, inputID GetCommentInput) (Comment, campaign.Campaign, error) {
+	targetCampaign, err := s.findCampaign(ctx, inputID.CampaignID)
+	if err != nil {
+		return Comment{}, targetCampaign, err
+	}
+
+	comment, err := s.repository.FindByID(ctx, inputID.ID)
+	if err != nil {
+		return comment, targetCampaign, err
+	}
+	if comment.ID == 0 || comment.CampaignID != targetCampaign.ID || comment.IsDeleted() {
+		return comment, targetCampaign, ErrCommentNotFound
+	}
+	return comment, targetCampaign, nil
+}
+
+// markCreator nandain komentar (dan balasannya) yang ditulis pemilik campaign, buat nampilin lencana kreator.
+func markCreator(comment *Comment, targetCampaign campaign.Campaign) {
+	comment.IsCreator = comment.UserID == targetCampaign.UserId
+	for i := range comment.Replies {
+		markCreator(&comment.Replies[i], targetCampaign)
+	}
+}
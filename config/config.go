@@ -0,0 +1,492 @@
+// Package config menyediakan konfigurasi aplikasi yang dibaca dari environment, file .env, dan file YAML.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config adalah semua pengaturan aplikasi.
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	JWT      JWTConfig      `yaml:"jwt"`
+	Storage  StorageConfig  `yaml:"storage"`
+	Payment  PaymentConfig  `yaml:"payment"`
+	Mail     MailConfig     `yaml:"mail"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+}
+
+// ServerConfig adalah pengaturan web server.
+type ServerConfig struct {
+	Port           string                   `yaml:"port"`            // Port HTTP, default 8080.
+	AppURL         string                   `yaml:"app_url"`         // Alamat aplikasi buat tautan di email, misal https://campaignku.id.
+	RequestTimeout time.Duration            `yaml:"request_timeout"` // Batas waktu tiap request, 0 artinya nggak dibatesin.
+	RouteTimeouts  map[string]time.Duration `yaml:"route_timeouts"`  // Batas waktu khusus per route, kuncinya "METHOD /path" sesuai route-nya, misal "GET /api/v1/campaigns/:id".
+
+	ReadTimeout       time.Duration `yaml:"read_timeout"`        // Batas waktu baca satu request lengkap sama body-nya.
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"` // Batas waktu baca header request.
+	WriteTimeout      time.Duration `yaml:"write_timeout"`       // Batas waktu nulis respons, harus lebih lama dari batas waktu request.
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`        // Lama koneksi keep-alive dibiarin nganggur.
+	MaxHeaderBytes    int           `yaml:"max_header_bytes"`    // Ukuran maksimal header request.
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`    // Lama nunggu request dan job yang lagi jalan pas server dimatiin.
+	TLSCertFile       string        `yaml:"tls_cert_file"`       // File sertifikat TLS. Kosong artinya server jalan tanpa TLS.
+	TLSKeyFile        string        `yaml:"tls_key_file"`        // File private key TLS.
+	MetricsToken      string        `yaml:"metrics_token"`       // Token Bearer buat ngakses /metrics. Kosong artinya /metrics terbuka.
+}
+
+// TLSEnabled ngecek server perlu jalan pake TLS apa enggak.
+func (c ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSKeyFile != ""
+}
+
+// TimeoutFor balikin batas waktu request buat sebuah route. Route yang nggak diatur khusus pake RequestTimeout.
+func (c ServerConfig) TimeoutFor(method string, path string) time.Duration {
+	if timeout, ok := c.RouteTimeouts[method+" "+path]; ok {
+		return timeout
+	}
+	return c.RequestTimeout
+}
+
+// Driver database yang didukung.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// DatabaseConfig adalah pengaturan koneksi database.
+type DatabaseConfig struct {
+	Driver   string `yaml:"driver"` // "mysql", "postgres", atau "sqlite", default mysql.
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Host     string `yaml:"host"` // Host plus port, misal localhost:3306 atau localhost:5432.
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"ssl_mode"` // Mode SSL PostgreSQL, default disable.
+	Path     string `yaml:"path"`     // Lokasi file SQLite, ":memory:" buat database di memori.
+}
+
+// DSN nyusun data source name dari pengaturan database sesuai driver-nya.
+func (c DatabaseConfig) DSN() string {
+	switch c.Driver {
+	case DriverPostgres:
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(c.User, c.Password),
+			Host:     c.Host,
+			Path:     "/" + c.Name,
+			RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+		}
+		return dsn.String()
+	case DriverSQLite:
+		// Foreign key di SQLite harus dinyalain per koneksi, busy_timeout biar nunggu dulu kalo database-nya lagi dikunci.
+		return "file:" + c.Path + "?_foreign_keys=on&_busy_timeout=5000"
+	default:
+		return c.User + ":" + c.Password + "@tcp(" + c.Host + ")/" + c.Name + "?charset=utf8mb4&parseTime=True&loc=Local"
+	}
+}
+
+// JWTConfig adalah pengaturan token login.
+type JWTConfig struct {
+	SecretKey string        `yaml:"secret_key"`
+	TokenTTL  time.Duration `yaml:"token_ttl"` // Lama token berlaku, 0 artinya nggak pernah kadaluarsa.
+}
+
+// StorageConfig adalah pengaturan penyimpanan file unggahan.
+type StorageConfig struct {
+	ImageDir string `yaml:"image_dir"` // Folder buat nyimpen gambar, default "images".
+}
+
+// PaymentConfig adalah pengaturan payment gateway Midtrans.
+type PaymentConfig struct {
+	MidtransServerKey  string `yaml:"midtrans_server_key"`
+	MidtransProduction bool   `yaml:"midtrans_production"`
+}
+
+// MailConfig adalah pengaturan pengiriman email.
+type MailConfig struct {
+	Driver       string `yaml:"driver"` // "smtp" atau "file".
+	From         string `yaml:"from"`
+	Dir          string `yaml:"dir"` // Folder tujuan kalo driver-nya "file".
+	SMTPHost     string `yaml:"smtp_host"`
+	SMTPPort     string `yaml:"smtp_port"`
+	SMTPUsername string `yaml:"smtp_username"`
+	SMTPPassword string `yaml:"smtp_password"`
+	Workers      int    `yaml:"workers"`    // Jumlah worker yang ngirim email.
+	QueueSize    int    `yaml:"queue_size"` // Kapasitas antrean email.
+}
+
+// LogConfig adalah pengaturan log aplikasi.
+type LogConfig struct {
+	Level  string `yaml:"level"`  // "debug", "info", "warn", atau "error".
+	Format string `yaml:"format"` // "json" buat production, "text" biar gampang dibaca waktu development.
+}
+
+// Pilihan tujuan pengiriman trace.
+const (
+	TracingExporterNone   = "none"   // Trace nggak dikirim ke mana-mana, header trace-context tetep diterusin.
+	TracingExporterStdout = "stdout" // Trace ditulis ke stdout, buat development.
+	TracingExporterOTLP   = "otlp"   // Trace dikirim ke collector OTLP lewat HTTP.
+)
+
+// TracingConfig adalah pengaturan tracing OpenTelemetry. Alamat dan header collector OTLP diatur lewat
+// environment variable standar OpenTelemetry, misal OTEL_EXPORTER_OTLP_ENDPOINT dan OTEL_EXPORTER_OTLP_HEADERS.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter"`     // "none", "stdout", atau "otlp".
+	ServiceName string  `yaml:"service_name"` // Nama service yang muncul di trace.
+	SampleRatio float64 `yaml:"sample_ratio"` // Porsi trace baru yang disimpen, 0 sampe 1. Trace dari upstream ngikutin keputusan upstream.
+}
+
+// Default balikin konfigurasi bawaan sebelum ditimpa file YAML dan environment.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:           "8080",
+			RequestTimeout: 15 * time.Second,
+			RouteTimeouts: map[string]time.Duration{
+				// Stream progress dibuka terus selama halaman campaign dibuka, jadi nggak dibatesin.
+				"GET /api/v1/campaigns/:id/stream": 0,
+				// Daftar pengiriman campaign besar bisa lama dibikinnya.
+				"GET /api/v1/campaigns/:id/shipping.csv": time.Minute,
+			},
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      90 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{Driver: DriverMySQL, SSLMode: "disable", Path: "campaignku.db"},
+		JWT:      JWTConfig{TokenTTL: 7 * 24 * time.Hour},
+		Storage:  StorageConfig{ImageDir: "images"},
+		Mail: MailConfig{
+			Driver:    "file",
+			From:      "Campaignku <no-reply@campaignku.id>",
+			Dir:       "mails",
+			SMTPPort:  "587",
+			Workers:   4,
+			QueueSize: 256,
+		},
+		Log:     LogConfig{Level: "info", Format: "json"},
+		Tracing: TracingConfig{Exporter: TracingExporterNone, ServiceName: "campaignku", SampleRatio: 1},
+	}
+}
+
+// Load baca konfigurasi lengkap buat nyalain web server, terus ngecek semuanya lewat Validate.
+func Load() (Config, error) {
+	cfg, err := read()
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// LoadDatabase baca konfigurasi yang sama kayak Load, tapi cuma ngecek pengaturan database.
+// Dipake perintah command line (migrate, user, seed, dst.) yang nggak butuh JWT, Midtrans, atau folder gambar.
+func LoadDatabase() (Config, error) {
+	cfg, err := read()
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.ValidateDatabase()
+}
+
+// read baca konfigurasi dengan urutan: nilai bawaan, file YAML dari CONFIG_FILE (kalo diisi), lalu environment.
+// File .env cuma dibaca kalo ada, jadi di container env var bisa langsung di-inject tanpa file .env.
+// Variabel yang udah ada di environment nggak ditimpa sama isi .env.
+func read() (Config, error) {
+	cfg := Default()
+
+	if _, err := os.Stat(".env"); err == nil {
+		if err := godotenv.Load(); err != nil {
+			return cfg, fmt.Errorf("gagal membaca .env: %w", err)
+		}
+	}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, loadEnv(&cfg)
+}
+
+// loadFile nimpa konfigurasi pake isi file YAML. Kolom yang nggak ada di file tetep pake nilai sebelumnya.
+func loadFile(path string, cfg *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gagal membaca file konfigurasi %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return fmt.Errorf("file konfigurasi %s tidak valid: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv nimpa konfigurasi pake environment variable yang diisi.
+func loadEnv(cfg *Config) error {
+	var errs []error
+
+	envString("PORT", &cfg.Server.Port)
+	envString("APP_URL", &cfg.Server.AppURL)
+	errs = append(errs, envDuration("REQUEST_TIMEOUT", &cfg.Server.RequestTimeout))
+	errs = append(errs, envDuration("SERVER_READ_TIMEOUT", &cfg.Server.ReadTimeout))
+	errs = append(errs, envDuration("SERVER_READ_HEADER_TIMEOUT", &cfg.Server.ReadHeaderTimeout))
+	errs = append(errs, envDuration("SERVER_WRITE_TIMEOUT", &cfg.Server.WriteTimeout))
+	errs = append(errs, envDuration("SERVER_IDLE_TIMEOUT", &cfg.Server.IdleTimeout))
+	errs = append(errs, envInt("SERVER_MAX_HEADER_BYTES", &cfg.Server.MaxHeaderBytes))
+	errs = append(errs, envDuration("SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout))
+	envString("TLS_CERT_FILE", &cfg.Server.TLSCertFile)
+	envString("TLS_KEY_FILE", &cfg.Server.TLSKeyFile)
+	envString("METRICS_TOKEN", &cfg.Server.MetricsToken)
+
+	envString("DB_DRIVER", &cfg.Database.Driver)
+	envString("DB_USER", &cfg.Database.User)
+	envString("DB_PASSWORD", &cfg.Database.Password)
+	envString("DB_HOST", &cfg.Database.Host)
+	envString("DB_NAME", &cfg.Database.Name)
+	envString("DB_SSL_MODE", &cfg.Database.SSLMode)
+	envString("DB_PATH", &cfg.Database.Path)
+
+	envString("JWT_SECRET_KEY", &cfg.JWT.SecretKey)
+	errs = append(errs, envDuration("JWT_TOKEN_TTL", &cfg.JWT.TokenTTL))
+
+	envString("STORAGE_IMAGE_DIR", &cfg.Storage.ImageDir)
+
+	envString("MIDTRANS_SERVER_KEY", &cfg.Payment.MidtransServerKey)
+	errs = append(errs, envBool("MIDTRANS_PRODUCTION", &cfg.Payment.MidtransProduction))
+
+	envString("MAIL_DRIVER", &cfg.Mail.Driver)
+	envString("MAIL_FROM", &cfg.Mail.From)
+	envString("MAIL_DIR", &cfg.Mail.Dir)
+	envString("SMTP_HOST", &cfg.Mail.SMTPHost)
+	envString("SMTP_PORT", &cfg.Mail.SMTPPort)
+	envString("SMTP_USERNAME", &cfg.Mail.SMTPUsername)
+	envString("SMTP_PASSWORD", &cfg.Mail.SMTPPassword)
+	errs = append(errs, envInt("MAIL_WORKERS", &cfg.Mail.Workers))
+	errs = append(errs, envInt("MAIL_QUEUE_SIZE", &cfg.Mail.QueueSize))
+
+	envString("LOG_LEVEL", &cfg.Log.Level)
+	envString("LOG_FORMAT", &cfg.Log.Format)
+
+	envString("TRACING_EXPORTER", &cfg.Tracing.Exporter)
+	envString("OTEL_SERVICE_NAME", &cfg.Tracing.ServiceName)
+	errs = append(errs, envFloat("TRACING_SAMPLE_RATIO", &cfg.Tracing.SampleRatio))
+
+	return errors.Join(errs...)
+}
+
+// Validate ngecek pengaturan yang wajib diisi dan nilainya masuk akal. Semua masalahnya dibalikin sekaligus.
+func (c Config) Validate() error {
+	var errs []error
+
+	required := []struct {
+		value string
+		name  string
+	}{
+		{c.JWT.SecretKey, "jwt.secret_key (JWT_SECRET_KEY)"},
+		{c.Payment.MidtransServerKey, "payment.midtrans_server_key (MIDTRANS_SERVER_KEY)"},
+		{c.Server.Port, "server.port (PORT)"},
+		{c.Storage.ImageDir, "storage.image_dir (STORAGE_IMAGE_DIR)"},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			errs = append(errs, fmt.Errorf("%s wajib diisi", field.name))
+		}
+	}
+
+	if err := c.ValidateDatabase(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Server.RequestTimeout < 0 {
+		errs = append(errs, errors.New("server.request_timeout (REQUEST_TIMEOUT) tidak boleh negatif"))
+	}
+	for route, timeout := range c.Server.RouteTimeouts {
+		if timeout < 0 {
+			errs = append(errs, fmt.Errorf("server.route_timeouts %q tidak boleh negatif", route))
+		}
+		if c.Server.WriteTimeout > 0 && timeout > c.Server.WriteTimeout {
+			errs = append(errs, fmt.Errorf("server.route_timeouts %q lebih lama dari server.write_timeout (SERVER_WRITE_TIMEOUT), responsnya bakal keputus duluan", route))
+		}
+	}
+	if c.Server.WriteTimeout > 0 && c.Server.RequestTimeout > c.Server.WriteTimeout {
+		errs = append(errs, errors.New("server.request_timeout (REQUEST_TIMEOUT) lebih lama dari server.write_timeout (SERVER_WRITE_TIMEOUT), responsnya bakal keputus duluan"))
+	}
+
+	durations := []struct {
+		value time.Duration
+		name  string
+	}{
+		{c.Server.ReadTimeout, "server.read_timeout (SERVER_READ_TIMEOUT)"},
+		{c.Server.ReadHeaderTimeout, "server.read_header_timeout (SERVER_READ_HEADER_TIMEOUT)"},
+		{c.Server.WriteTimeout, "server.write_timeout (SERVER_WRITE_TIMEOUT)"},
+		{c.Server.IdleTimeout, "server.idle_timeout (SERVER_IDLE_TIMEOUT)"},
+	}
+	for _, field := range durations {
+		if field.value < 0 {
+			errs = append(errs, fmt.Errorf("%s tidak boleh negatif", field.name))
+		}
+	}
+	if c.Server.MaxHeaderBytes < 0 {
+		errs = append(errs, errors.New("server.max_header_bytes (SERVER_MAX_HEADER_BYTES) tidak boleh negatif"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout (SHUTDOWN_TIMEOUT) harus lebih dari 0"))
+	}
+	if c.Server.TLSEnabled() && (c.Server.TLSCertFile == "" || c.Server.TLSKeyFile == "") {
+		errs = append(errs, errors.New("server.tls_cert_file (TLS_CERT_FILE) dan server.tls_key_file (TLS_KEY_FILE) harus diisi dua-duanya"))
+	}
+
+	if c.JWT.TokenTTL < 0 {
+		errs = append(errs, errors.New("jwt.token_ttl (JWT_TOKEN_TTL) tidak boleh negatif"))
+	}
+
+	switch c.Mail.Driver {
+	case "smtp":
+		if c.Mail.SMTPHost == "" {
+			errs = append(errs, errors.New("mail.smtp_host (SMTP_HOST) wajib diisi kalau mail.driver smtp"))
+		}
+		if c.Mail.SMTPPort == "" {
+			errs = append(errs, errors.New("mail.smtp_port (SMTP_PORT) wajib diisi kalau mail.driver smtp"))
+		}
+	case "file":
+		if c.Mail.Dir == "" {
+			errs = append(errs, errors.New("mail.dir (MAIL_DIR) wajib diisi kalau mail.driver file"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("mail.driver (MAIL_DRIVER) harus smtp atau file, bukan %q", c.Mail.Driver))
+	}
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level (LOG_LEVEL) harus debug, info, warn, atau error, bukan %q", c.Log.Level))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		errs = append(errs, fmt.Errorf("log.format (LOG_FORMAT) harus json atau text, bukan %q", c.Log.Format))
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout, TracingExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter (TRACING_EXPORTER) harus none, stdout, atau otlp, bukan %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.ServiceName == "" {
+		errs = append(errs, errors.New("tracing.service_name (OTEL_SERVICE_NAME) wajib diisi"))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio (TRACING_SAMPLE_RATIO) harus di antara 0 dan 1"))
+	}
+	if c.Mail.Workers < 1 {
+		errs = append(errs, errors.New("mail.workers (MAIL_WORKERS) minimal 1"))
+	}
+	if c.Mail.QueueSize < 1 {
+		errs = append(errs, errors.New("mail.queue_size (MAIL_QUEUE_SIZE) minimal 1"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateDatabase ngecek pengaturan database aja. Validate juga manggil ini, jadi aturannya nggak dobel.
+func (c Config) ValidateDatabase() error {
+	var errs []error
+
+	switch c.Database.Driver {
+	case DriverMySQL, DriverPostgres:
+		if c.Database.User == "" {
+			errs = append(errs, errors.New("database.user (DB_USER) wajib diisi"))
+		}
+		if c.Database.Host == "" {
+			errs = append(errs, errors.New("database.host (DB_HOST) wajib diisi"))
+		}
+		if c.Database.Name == "" {
+			errs = append(errs, errors.New("database.name (DB_NAME) wajib diisi"))
+		}
+	case DriverSQLite:
+		if c.Database.Path == "" {
+			errs = append(errs, errors.New("database.path (DB_PATH) wajib diisi kalau database.driver sqlite"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("database.driver (DB_DRIVER) harus mysql, postgres, atau sqlite, bukan %q", c.Database.Driver))
+	}
+
+	return errors.Join(errs...)
+}
+
+// envString ngisi target dari environment variable kalo variabelnya diisi.
+func envString(name string, target *string) {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		*target = value
+	}
+}
+
+// envInt ngisi target dari environment variable berupa angka.
+func envInt(name string, target *int) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%s harus berupa angka, bukan %q", name, value)
+	}
+	*target = number
+	return nil
+}
+
+// envBool ngisi target dari environment variable berupa true atau false.
+func envBool(name string, target *bool) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%s harus true atau false, bukan %q", name, value)
+	}
+	*target = flag
+	return nil
+}
+
+// envFloat ngisi target dari environment variable berupa angka desimal, misal 0.25.
+func envFloat(name string, target *float64) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%s harus berupa angka desimal, bukan %q", name, value)
+	}
+	*target = number
+	return nil
+}
+
+// envDuration ngisi target dari environment variable berupa durasi, misal "24h" atau "30m".
+func envDuration(name string, target *time.Duration) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%s harus berupa durasi seperti 24h atau 30m, bukan %q", name, value)
+	}
+	*target = duration
+	return nil
+}
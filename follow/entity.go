@@ -0,0 +1,47 @@
+// Package follow menyediakan fitur ngikutin campaign dan kreator, plus linimasa dari yang diikutin.
+package follow
+
+import (
+	"campaignku/campaign"
+	"time"
+)
+
+// Jenis item di linimasa.
+const (
+	ItemNewCampaign    = "new_campaign"    // Campaign baru dari kreator yang diikutin.
+	ItemCampaignUpdate = "campaign_update" // Kabar terbaru dari campaign yang diikutin.
+)
+
+// CampaignFollow nyatet user yang ngikutin sebuah campaign. Pasangan user_id dan campaign_id harus unik.
+type CampaignFollow struct {
+	ID         int
+	UserID     int
+	CampaignID int
+	CreatedAt  time.Time
+}
+
+// CreatorFollow nyatet user yang ngikutin seorang kreator. Pasangan user_id dan creator_id harus unik.
+type CreatorFollow struct {
+	ID        int
+	UserID    int
+	CreatorID int
+	CreatedAt time.Time
+}
+
+// FeedEntry adalah satu baris mentah linimasa, sebelum data campaign dan kabar terbarunya diambil.
+type FeedEntry struct {
+	ItemType   string
+	ID         int
+	CampaignID int
+	CreatedAt  time.Time
+}
+
+// FeedItem adalah satu item linimasa yang udah lengkap.
+// Update cuma diisi kalo jenisnya ItemCampaignUpdate.
+type FeedItem struct {
+	Type               string
+	Campaign           campaign.Campaign
+	Update             *campaign.CampaignUpdate
+	CanViewBackersOnly bool // Boleh baca isi kabar yang khusus backer apa enggak.
+	CreatedAt          time.Time
+}
@@ -0,0 +1,48 @@
+package follow
+
+import (
+	"campaignku/campaign"
+	"time"
+)
+
+// FollowFormatter adalah struktur data buat respons follow dan unfollow.
+type FollowFormatter struct {
+	Following     bool `json:"following"`
+	FollowerCount int  `json:"follower_count"`
+}
+
+// FormatFollow mengonversi status follow dan jumlah pengikut menjadi FollowFormatter.
+func FormatFollow(following bool, followerCount int) FollowFormatter {
+	return FollowFormatter{
+		Following:     following,
+		FollowerCount: followerCount,
+	}
+}
+
+// FeedItemFormatter adalah struktur data buat satu item linimasa.
+type FeedItemFormatter struct {
+	Type      string                            `json:"type"`
+	Campaign  campaign.CampaignFormatter        `json:"campaign"`
+	Update    *campaign.CampaignUpdateFormatter `json:"update"`
+	CreatedAt time.Time                         `json:"created_at"`
+}
+
+// FormatFeed mengonversi item linimasa menjadi daftar FeedItemFormatter.
+func FormatFeed(items []FeedItem) []FeedItemFormatter {
+	itemsFormatter := []FeedItemFormatter{}
+
+	for _, item := range items {
+		itemFormatter := FeedItemFormatter{
+			Type:      item.Type,
+			Campaign:  campaign.FormatCampaign(item.Campaign),
+			CreatedAt: item.CreatedAt,
+		}
+		if item.Update != nil {
+			update := campaign.FormatCampaignUpdate(*item.Update, item.CanViewBackersOnly)
+			itemFormatter.Update = &update
+		}
+		itemsFormatter = append(itemsFormatter, itemFormatter)
+	}
+
+	return itemsFormatter
+}
@@ -0,0 +1,12 @@
+package follow
+
+// GetCreatorInput adalah struktur data buat nampung ID kreator dari URI.
+type GetCreatorInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// GetFeedInput adalah struktur data buat nampung query string paginasi saat minta linimasa.
+type GetFeedInput struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
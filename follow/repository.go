@@ -0,0 +1,116 @@
+package follow
+
+import (
+	"campaignku/campaign"
+	"campaignku/user"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// feedQuery nyatuin campaign baru dari kreator yang diikutin dan kabar terbaru dari campaign yang diikutin jadi satu linimasa.
+const feedQuery = `
+SELECT 'new_campaign' AS item_type, id, id AS campaign_id, created_at
+FROM campaigns
+WHERE user_id IN (SELECT creator_id FROM creator_follows WHERE user_id = @user)
+UNION ALL
+SELECT 'campaign_update' AS item_type, id, campaign_id, created_at
+FROM campaign_updates
+WHERE campaign_id IN (SELECT campaign_id FROM campaign_follows WHERE user_id = @user)`
+
+// Repository adalah interface untuk fungsi-fungsi database yang berkaitan dengan follow.
+type Repository interface {
+	FollowCampaign(ctx context.Context, userID int, campaignID int) error                      // Fungsi untuk ngikutin campaign, nggak ngapa-ngapain kalo udah ngikutin.
+	UnfollowCampaign(ctx context.Context, userID int, campaignID int) error                    // Fungsi untuk berhenti ngikutin campaign.
+	FollowCreator(ctx context.Context, userID int, creatorID int) error                        // Fungsi untuk ngikutin kreator, nggak ngapa-ngapain kalo udah ngikutin.
+	UnfollowCreator(ctx context.Context, userID int, creatorID int) error                      // Fungsi untuk berhenti ngikutin kreator.
+	FindFeed(ctx context.Context, userID int, page int, limit int) ([]FeedEntry, int64, error) // Fungsi untuk dapetin linimasa user per halaman, yang paling baru duluan.
+}
+
+// repository adalah implementasi dari Repository, pakai GORM.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository adalah fungsi pembuat repository baru.
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+// FollowCampaign adalah method dari repository untuk ngikutin campaign.
+// Jumlah pengikut campaign cuma ditambah kalo barisnya beneran baru, jadi follow dua kali nggak bikin hitungannya dobel.
+func (r *repository) FollowCampaign(ctx context.Context, userID int, campaignID int) error {
+	follow := CampaignFollow{UserID: userID, CampaignID: campaignID, CreatedAt: time.Now()}
+	return r.follow(ctx, &follow, &campaign.Campaign{ID: campaignID})
+}
+
+// UnfollowCampaign adalah method dari repository untuk berhenti ngikutin campaign.
+func (r *repository) UnfollowCampaign(ctx context.Context, userID int, campaignID int) error {
+	return r.unfollow(ctx, &CampaignFollow{}, &campaign.Campaign{ID: campaignID}, "user_id = ? AND campaign_id = ?", userID, campaignID)
+}
+
+// FollowCreator adalah method dari repository untuk ngikutin kreator.
+func (r *repository) FollowCreator(ctx context.Context, userID int, creatorID int) error {
+	follow := CreatorFollow{UserID: userID, CreatorID: creatorID, CreatedAt: time.Now()}
+	return r.follow(ctx, &follow, &user.User{ID: creatorID})
+}
+
+// UnfollowCreator adalah method dari repository untuk berhenti ngikutin kreator.
+func (r *repository) UnfollowCreator(ctx context.Context, userID int, creatorID int) error {
+	return r.unfollow(ctx, &CreatorFollow{}, &user.User{ID: creatorID}, "user_id = ? AND creator_id = ?", userID, creatorID)
+}
+
+// follow nyimpen baris follow baru dan nambah follower_count target-nya dalam satu transaksi database.
+func (r *repository) follow(ctx context.Context, follow interface{}, target interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(follow)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return tx.Model(target).UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error
+	})
+}
+
+// unfollow ngapus baris follow dan ngurangin follower_count target-nya dalam satu transaksi database.
+func (r *repository) unfollow(ctx context.Context, follow interface{}, target interface{}, query string, args ...interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where(query, args...).Delete(follow)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return tx.Model(target).
+			Where("follower_count > 0").
+			UpdateColumn("follower_count", gorm.Expr("follower_count - 1")).Error
+	})
+}
+
+// FindFeed adalah method dari repository untuk dapetin linimasa user per halaman, yang paling baru duluan.
+// Repository ngambil satu data lebih, buat tau masih ada halaman berikutnya apa enggak.
+func (r *repository) FindFeed(ctx context.Context, userID int, page int, limit int) ([]FeedEntry, int64, error) {
+	var entries []FeedEntry
+	var total int64
+
+	args := map[string]interface{}{"user": userID}
+
+	err := r.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM ("+feedQuery+") AS feed", args).Scan(&total).Error
+	if err != nil {
+		return entries, total, err
+	}
+
+	args["limit"] = limit + 1
+	args["offset"] = (page - 1) * limit
+	err = r.db.WithContext(ctx).Raw(feedQuery+" ORDER BY created_at DESC, id DESC LIMIT @limit OFFSET @offset", args).
+		Scan(&entries).Error
+	if err != nil {
+		return entries, total, err
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,243 @@
+package follow
+
+import (
+	"campaignku/campaign"
+	"campaignku/helper"
+	"campaignku/user"
+	"context"
+	"errors"
+)
+
+// Batas default jumlah item linimasa per halaman.
+const defaultLimit = 20
+
+// Error yang bisa dibalikin sama service follow.
+var (
+	ErrCreatorNotFound = errors.New("kreator tidak ditemukan")
+	ErrFollowSelf      = errors.New("tidak bisa mengikuti diri sendiri")
+)
+
+// Service adalah interface yang mendefinisikan fungsi yang harus ada di service follow.
+type Service interface {
+	FollowCampaign(ctx context.Context, inputID campaign.GetCampaignDetailInput, user user.User) (int, error)   // Fungsi buat ngikutin campaign, balikin jumlah pengikutnya.
+	UnfollowCampaign(ctx context.Context, inputID campaign.GetCampaignDetailInput, user user.User) (int, error) // Fungsi buat berhenti ngikutin campaign, balikin jumlah pengikutnya.
+	FollowCreator(ctx context.Context, inputID GetCreatorInput, user user.User) (int, error)                    // Fungsi buat ngikutin kreator, balikin jumlah pengikutnya.
+	UnfollowCreator(ctx context.Context, inputID GetCreatorInput, user user.User) (int, error)                  // Fungsi buat berhenti ngikutin kreator, balikin jumlah pengikutnya.
+	GetFeed(ctx context.Context, input GetFeedInput, user user.User) ([]FeedItem, helper.Pagination, error)     // Fungsi buat dapetin linimasa dari campaign dan kreator yang diikutin.
+}
+
+// service adalah struct yang implementasi dari Service.
+type service struct {
+	repository         Repository
+	campaignRepository campaign.Repository
+	userRepository     user.Repository
+}
+
+// NewService adalah fungsi pembuat service baru.
+func NewService(repository Repository, campaignRepository campaign.Repository, userRepository user.Repository) *service {
+	return &service{repository, campaignRepository, userRepository}
+}
+
+// FollowCampaign adalah method dari service buat ngikutin campaign.
+func (s *service) FollowCampaign(ctx context.Context, inputID campaign.GetCampaignDetailInput, user user.User) (int, error) {
+	if _, err := s.findCampaign(ctx, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	if err := s.repository.FollowCampaign(ctx, user.ID, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	return s.campaignFollowerCount(ctx, inputID.ID)
+}
+
+// UnfollowCampaign adalah method dari service buat berhenti ngikutin campaign.
+func (s *service) UnfollowCampaign(ctx context.Context, inputID campaign.GetCampaignDetailInput, user user.User) (int, error) {
+	if _, err := s.findCampaign(ctx, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	if err := s.repository.UnfollowCampaign(ctx, user.ID, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	return s.campaignFollowerCount(ctx, inputID.ID)
+}
+
+// FollowCreator adalah method dari service buat ngikutin kreator. User nggak bisa ngikutin dirinya sendiri.
+func (s *service) FollowCreator(ctx context.Context, inputID GetCreatorInput, user user.User) (int, error) {
+	if inputID.ID == user.ID {
+		return 0, ErrFollowSelf
+	}
+	if _, err := s.findCreator(ctx, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	if err := s.repository.FollowCreator(ctx, user.ID, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	return s.creatorFollowerCount(ctx, inputID.ID)
+}
+
+// UnfollowCreator adalah method dari service buat berhenti ngikutin kreator.
+func (s *service) UnfollowCreator(ctx context.Context, inputID GetCreatorInput, user user.User) (int, error) {
+	if _, err := s.findCreator(ctx, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	if err := s.repository.UnfollowCreator(ctx, user.ID, inputID.ID); err != nil {
+		return 0, err
+	}
+
+	return s.creatorFollowerCount(ctx, inputID.ID)
+}
+
+// GetFeed adalah method dari service buat dapetin linimasa user per halaman.
+// Isinya campaign baru dari kreator yang diikutin dan kabar terbaru dari campaign yang diikutin, yang paling baru duluan.
+func (s *service) GetFeed(ctx context.Context, input GetFeedInput, user user.User) ([]FeedItem, helper.Pagination, error) {
+	if input.Limit == 0 {
+		input.Limit = defaultLimit
+	}
+	if input.Page == 0 {
+		input.Page = 1
+	}
+
+	pagination := helper.Pagination{Page: input.Page, Limit: input.Limit}
+
+	entries, total, err := s.repository.FindFeed(ctx, user.ID, input.Page, input.Limit)
+	if err != nil {
+		return nil, pagination, err
+	}
+	pagination.Total = total
+
+	// Repository ngambil satu data lebih, jadi kalo kelebihan berarti masih ada halaman berikutnya.
+	if len(entries) > input.Limit {
+		entries = entries[:input.Limit]
+		pagination.HasMore = true
+	}
+
+	items, err := s.loadFeedItems(ctx, entries, user)
+	if err != nil {
+		return nil, pagination, err
+	}
+
+	return items, pagination, nil
+}
+
+// loadFeedItems ngambil data campaign dan kabar terbaru buat baris-baris linimasa, terus nyusunnya sesuai urutan aslinya.
+func (s *service) loadFeedItems(ctx context.Context, entries []FeedEntry, user user.User) ([]FeedItem, error) {
+	var campaignIDs, updateIDs []int
+	seen := make(map[int]bool)
+	for _, entry := range entries {
+		if !seen[entry.CampaignID] {
+			seen[entry.CampaignID] = true
+			campaignIDs = append(campaignIDs, entry.CampaignID)
+		}
+		if entry.ItemType == ItemCampaignUpdate {
+			updateIDs = append(updateIDs, entry.ID)
+		}
+	}
+
+	campaigns, err := s.campaignRepository.FindByIDs(ctx, campaignIDs)
+	if err != nil {
+		return nil, err
+	}
+	campaignsByID := make(map[int]campaign.Campaign, len(campaigns))
+	for _, campaign := range campaigns {
+		campaignsByID[campaign.ID] = campaign
+	}
+
+	updates, err := s.campaignRepository.FindUpdatesByIDs(ctx, updateIDs)
+	if err != nil {
+		return nil, err
+	}
+	updatesByID := make(map[int]campaign.CampaignUpdate, len(updates))
+	for _, update := range updates {
+		updatesByID[update.ID] = update
+	}
+
+	// Hak baca kabar khusus backer dicek sekali per campaign aja.
+	canViewByCampaign := make(map[int]bool)
+
+	items := make([]FeedItem, 0, len(entries))
+	for _, entry := range entries {
+		targetCampaign, ok := campaignsByID[entry.CampaignID]
+		if !ok {
+			continue
+		}
+
+		item := FeedItem{
+			Type:      entry.ItemType,
+			Campaign:  targetCampaign,
+			CreatedAt: entry.CreatedAt,
+		}
+
+		if entry.ItemType == ItemCampaignUpdate {
+			update, ok := updatesByID[entry.ID]
+			if !ok {
+				continue
+			}
+			item.Update = &update
+
+			canView, checked := canViewByCampaign[targetCampaign.ID]
+			if !checked {
+				canView = targetCampaign.UserId == user.ID
+				if !canView {
+					canView, err = s.campaignRepository.IsBacker(ctx, targetCampaign.ID, user.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				canViewByCampaign[targetCampaign.ID] = canView
+			}
+			item.CanViewBackersOnly = canView
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// findCampaign ngambil campaign dan mastiin campaign-nya ada.
+func (s *service) findCampaign(ctx context.Context, campaignID int) (campaign.Campaign, error) {
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, campaignID)
+	if err != nil {
+		return targetCampaign, err
+	}
+	if targetCampaign.ID == 0 {
+		return targetCampaign, campaign.ErrCampaignNotFound
+	}
+	return targetCampaign, nil
+}
+
+// findCreator ngambil user yang mau diikutin dan mastiin user-nya ada.
+func (s *service) findCreator(ctx context.Context, creatorID int) (user.User, error) {
+	creator, err := s.userRepository.FindByID(ctx, creatorID)
+	if err != nil {
+		return creator, err
+	}
+	if creator.ID == 0 {
+		return creator, ErrCreatorNotFound
+	}
+	return creator, nil
+}
+
+// campaignFollowerCount ngambil jumlah pengikut campaign yang terbaru dari database.
+func (s *service) campaignFollowerCount(ctx context.Context, campaignID int) (int, error) {
+	targetCampaign, err := s.findCampaign(ctx, campaignID)
+	if err != nil {
+		return 0, err
+	}
+	return targetCampaign.FollowerCount, nil
+}
+
+// creatorFollowerCount ngambil jumlah pengikut kreator yang terbaru dari database.
+func (s *service) creatorFollowerCount(ctx context.Context, creatorID int) (int, error) {
+	creator, err := s.findCreator(ctx, creatorID)
+	if err != nil {
+		return 0, err
+	}
+	return creator.FollowerCount, nil
+}
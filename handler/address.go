@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"campaignku/helper"
+	"campaignku/user"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAddresses menangani permintaan daftar alamat milik pengguna yang lagi login.
+func (h *usersHandler) GetAddresses(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	addresses, err := h.userService.GetAddresses(c.Request.Context(), currentUser.ID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat alamat", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Daftar alamat", http.StatusOK, "success", user.FormatAddresses(addresses))
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateAddress menangani permintaan penambahan alamat ke buku alamat.
+func (h *usersHandler) CreateAddress(c *gin.Context) {
+	var input user.AddressInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal menambah alamat", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newAddress, err := h.userService.CreateAddress(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menambah alamat", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Alamat berhasil ditambahkan", http.StatusOK, "success", user.FormatAddress(newAddress))
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateAddress menangani permintaan perubahan alamat.
+func (h *usersHandler) UpdateAddress(c *gin.Context) {
+	var inputID user.GetAddressInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah alamat", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input user.AddressInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah alamat", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	updatedAddress, err := h.userService.UpdateAddress(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondAddressError(c, "Gagal mengubah alamat", err)
+		return
+	}
+
+	response := helper.ApiResponse("Alamat berhasil diubah", http.StatusOK, "success", user.FormatAddress(updatedAddress))
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteAddress menangani permintaan penghapusan alamat.
+func (h *usersHandler) DeleteAddress(c *gin.Context) {
+	var inputID user.GetAddressInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menghapus alamat", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	err = h.userService.DeleteAddress(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondAddressError(c, "Gagal menghapus alamat", err)
+		return
+	}
+
+	response := helper.ApiResponse("Alamat berhasil dihapus", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// respondAddressError milih kode status HTTP yang pas buat error alamat.
+func respondAddressError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	if errors.Is(err, user.ErrAddressNotFound) {
+		errorMessage := gin.H{"errors": err.Error()}
+		response := helper.ApiResponse(message, http.StatusNotFound, "error", errorMessage)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	response := helper.ApiResponse(message, http.StatusBadRequest, "error", nil)
+	c.JSON(http.StatusBadRequest, response)
+}
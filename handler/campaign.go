@@ -3,36 +3,486 @@ package handler
 import (
 	"campaignku/campaign"
 	"campaignku/helper"
+	"campaignku/user"
+	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
+	"path/filepath"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Struct buat handle campaign.
 type campaignHandler struct {
-	service campaign.Service // Service ini yang bakal urusin logika bisnis.
+	service  campaign.Service // Service ini yang bakal urusin logika bisnis.
+	imageDir string           // Folder buat nyimpen gambar campaign yang diunggah.
 }
 
 // Fungsi buat bikin handler campaign baru.
-func NewCampaignHandler(service campaign.Service) *campaignHandler {
-	return &campaignHandler{service} // Balikin struct campaignHandler yang baru.
+func NewCampaignHandler(service campaign.Service, imageDir string) *campaignHandler {
+	return &campaignHandler{service, imageDir} // Balikin struct campaignHandler yang baru.
 }
 
 // Method buat dapetin data campaign.
+// Query string yang didukung: user_id, min_goal, max_goal, funded, status, category, tag, sort, page, limit, dan cursor.
 func (h *campaignHandler) GetCampaigns(c *gin.Context) {
-	// Ambil user_id dari query, terus ubah jadi integer.
-	userID, _ := strconv.Atoi(c.Query("user_id"))
+	var input campaign.GetCampaignsInput
 
-	// Ambil data campaign dari service pake userID yang udah diambil.
-	campaigns, err := h.service.GetCampaigns(userID)
+	// Ambil filter dan paginasi dari query string.
+	err := c.ShouldBindQuery(&input)
 	if err != nil {
-		// Kalo ada error, balikin response error.
-		response := helper.ApiResponse("Error to get campaigns", http.StatusBadRequest, "error", nil)
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Error to get campaigns", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	// Ambil data campaign dari service sesuai filter yang udah diambil.
+	campaigns, pagination, err := h.service.GetCampaigns(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		// Cursor yang rusak itu salah input dari client, selain itu berarti ada masalah di database.
+		if errors.Is(err, campaign.ErrInvalidCursor) {
+			errorMessage := gin.H{"errors": err.Error()}
+			response := helper.ApiResponse("Error to get campaigns", http.StatusUnprocessableEntity, "error", errorMessage)
+			c.JSON(http.StatusUnprocessableEntity, response)
+			return
+		}
+		response := helper.ApiResponse("Error to get campaigns", http.StatusInternalServerError, "error", nil)
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+	// Kalo sukses, balikin response berisi daftar campaign plus info paginasinya.
+	response := helper.ApiResponseWithPagination("List of campaigns", http.StatusOK, "success", campaign.FormatCampaigns(campaigns), pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin detail satu campaign.
+func (h *campaignHandler) GetCampaign(c *gin.Context) {
+	var input campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat detail campaign", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	campaignDetail, err := h.service.GetCampaignByID(c.Request.Context(), input)
+	if err != nil {
+		respondCampaignError(c, "Gagal memuat detail campaign", err)
+		return
+	}
+
+	response := helper.ApiResponse("Detail campaign", http.StatusOK, "success", campaign.FormatCampaignDetail(campaignDetail))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nyari campaign berdasarkan kata di nama dan deskripsinya.
+func (h *campaignHandler) SearchCampaigns(c *gin.Context) {
+	var input campaign.SearchCampaignsInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mencari campaign", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	results, err := h.service.SearchCampaigns(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mencari campaign", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Hasil pencarian campaign", http.StatusOK, "success", campaign.FormatSearchResults(results))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat bikin campaign baru atas nama user yang lagi login.
+func (h *campaignHandler) CreateCampaign(c *gin.Context) {
+	var input campaign.CreateCampaignInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal membuat campaign", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	// Pemilik campaign diambil dari user yang login, bukan dari input.
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newCampaign, err := h.service.CreateCampaign(c.Request.Context(), input)
+	if err != nil {
+		respondCampaignError(c, "Gagal membuat campaign", err)
+		return
+	}
+
+	response := helper.ApiResponse("Campaign berhasil dibuat", http.StatusOK, "success", campaign.FormatCampaign(newCampaign))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah campaign. Cuma pemilik campaign yang boleh.
+func (h *campaignHandler) UpdateCampaign(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah campaign", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.CreateCampaignInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah campaign", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	updatedCampaign, err := h.service.UpdateCampaign(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCampaignError(c, "Gagal mengubah campaign", err)
+		return
+	}
+
+	response := helper.ApiResponse("Campaign berhasil diubah", http.StatusOK, "success", campaign.FormatCampaign(updatedCampaign))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nambah reward ke campaign. Cuma pemilik campaign yang boleh.
+func (h *campaignHandler) CreateReward(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal membuat reward", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.RewardInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal membuat reward", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newReward, err := h.service.CreateReward(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCampaignError(c, "Gagal membuat reward", err)
+		return
+	}
+
+	response := helper.ApiResponse("Reward berhasil dibuat", http.StatusOK, "success", campaign.FormatReward(newReward))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah reward. Cuma pemilik campaign yang boleh.
+func (h *campaignHandler) UpdateReward(c *gin.Context) {
+	var inputID campaign.GetRewardInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah reward", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.RewardInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah reward", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	updatedReward, err := h.service.UpdateReward(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCampaignError(c, "Gagal mengubah reward", err)
+		return
+	}
+
+	response := helper.ApiResponse("Reward berhasil diubah", http.StatusOK, "success", campaign.FormatReward(updatedReward))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngapus reward yang belum dipilih backer. Cuma pemilik campaign yang boleh.
+func (h *campaignHandler) DeleteReward(c *gin.Context) {
+	var inputID campaign.GetRewardInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menghapus reward", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	err = h.service.DeleteReward(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondCampaignError(c, "Gagal menghapus reward", err)
+		return
+	}
+
+	response := helper.ApiResponse("Reward berhasil dihapus", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin kabar terbaru sebuah campaign.
+// Endpoint ini publik, tapi isi kabar yang khusus backer cuma ditampilin ke backer dan pemilik campaign.
+func (h *campaignHandler) GetCampaignUpdates(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat kabar terbaru", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	updates, canViewBackersOnly, err := h.service.GetCampaignUpdates(c.Request.Context(), inputID, optionalCurrentUser(c))
+	if err != nil {
+		respondCampaignError(c, "Gagal memuat kabar terbaru", err)
+		return
+	}
+
+	response := helper.ApiResponse("Daftar kabar terbaru", http.StatusOK, "success", campaign.FormatCampaignUpdates(updates, canViewBackersOnly))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nerbitin kabar terbaru campaign. Cuma pemilik campaign yang boleh.
+func (h *campaignHandler) CreateCampaignUpdate(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menerbitkan kabar terbaru", http.StatusBadRequest, "error", nil)
 		c.JSON(http.StatusBadRequest, response)
 		return
 	}
-	// Kalo sukses, balikin response berisi daftar campaign.
-	response := helper.ApiResponse("List of campaigns", http.StatusOK, "success", campaign.FormatCampaigns(campaigns))
+
+	var input campaign.CampaignUpdateInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal menerbitkan kabar terbaru", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newUpdate, err := h.service.CreateCampaignUpdate(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCampaignError(c, "Gagal menerbitkan kabar terbaru", err)
+		return
+	}
+
+	response := helper.ApiResponse("Kabar terbaru berhasil diterbitkan", http.StatusOK, "success", campaign.FormatCampaignUpdate(newUpdate, true))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah kabar terbaru campaign. Cuma pemilik campaign yang boleh.
+func (h *campaignHandler) UpdateCampaignUpdate(c *gin.Context) {
+	var inputID campaign.GetCampaignUpdateInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah kabar terbaru", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.CampaignUpdateInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah kabar terbaru", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	updatedUpdate, err := h.service.UpdateCampaignUpdate(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCampaignError(c, "Gagal mengubah kabar terbaru", err)
+		return
+	}
+
+	response := helper.ApiResponse("Kabar terbaru berhasil diubah", http.StatusOK, "success", campaign.FormatCampaignUpdate(updatedUpdate, true))
 	c.JSON(http.StatusOK, response)
 }
+
+// Method buat ngapus kabar terbaru campaign. Cuma pemilik campaign yang boleh.
+func (h *campaignHandler) DeleteCampaignUpdate(c *gin.Context) {
+	var inputID campaign.GetCampaignUpdateInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menghapus kabar terbaru", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	err = h.service.DeleteCampaignUpdate(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondCampaignError(c, "Gagal menghapus kabar terbaru", err)
+		return
+	}
+
+	response := helper.ApiResponse("Kabar terbaru berhasil dihapus", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// UploadCampaignImage menangani pengunggahan gambar campaign, cuma boleh sama pemilik campaign.
+func (h *campaignHandler) UploadCampaignImage(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengunggah gambar campaign", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.CampaignImageInput
+	err = c.ShouldBind(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengunggah gambar campaign", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.Error(err)
+		data := gin.H{"is_uploaded": false}
+		response := helper.ApiResponse("Gagal mengunggah gambar campaign", http.StatusBadRequest, "error", data)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	// Cek dulu pemiliknya sebelum file-nya ditulis, biar orang lain nggak bisa ngisi folder gambar.
+	existingCampaign, err := h.service.GetCampaignByID(c.Request.Context(), inputID)
+	if err != nil {
+		respondCampaignError(c, "Gagal mengunggah gambar campaign", err)
+		return
+	}
+	if existingCampaign.UserId != input.User.ID {
+		respondCampaignError(c, "Gagal mengunggah gambar campaign", campaign.ErrNotOwner)
+		return
+	}
+
+	path := fmt.Sprintf("%s/campaign-%d-%s", h.imageDir, inputID.ID, filepath.Base(file.Filename))
+	err = c.SaveUploadedFile(file, path)
+	if err != nil {
+		c.Error(err)
+		data := gin.H{"is_uploaded": false}
+		response := helper.ApiResponse("Gagal mengunggah gambar campaign", http.StatusBadRequest, "error", data)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	_, err = h.service.SaveCampaignImage(c.Request.Context(), inputID, input, path)
+	if err != nil {
+		respondCampaignError(c, "Gagal mengunggah gambar campaign", err)
+		return
+	}
+
+	data := gin.H{"is_uploaded": true}
+	response := helper.ApiResponse("Gambar campaign berhasil diunggah", http.StatusOK, "success", data)
+	c.JSON(http.StatusOK, response)
+}
+
+// respondCampaignError milih kode status HTTP yang pas buat error dari service campaign.
+// Error lain yang nggak dikenal (misal error database) nggak ditampilin detailnya ke client.
+func respondCampaignError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, campaign.ErrCampaignNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, campaign.ErrNotOwner):
+		code = http.StatusForbidden
+	case errors.Is(err, campaign.ErrCategoryNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, campaign.ErrCategoryExists):
+		code = http.StatusConflict
+	case errors.Is(err, campaign.ErrRewardNotFound), errors.Is(err, campaign.ErrUpdateNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, campaign.ErrRewardInUse):
+		code = http.StatusConflict
+	case errors.Is(err, campaign.ErrCampaignClosed),
+		errors.Is(err, campaign.ErrInvalidSchedule),
+		errors.Is(err, campaign.ErrInvalidStartDate),
+		errors.Is(err, campaign.ErrCampaignStarted),
+		errors.Is(err, campaign.ErrInvalidQuantity):
+		code = http.StatusUnprocessableEntity
+	default:
+		response := helper.ApiResponse(message, code, "error", nil)
+		c.JSON(code, response)
+		return
+	}
+
+	errorMessage := gin.H{"errors": err.Error()}
+	response := helper.ApiResponse(message, code, "error", errorMessage)
+	c.JSON(code, response)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"campaignku/campaign"
+	"campaignku/helper"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubCampaignService balikin error yang udah ditentuin dari GetCampaigns dan nyatet apa dia sempet dipanggil.
+type stubCampaignService struct {
+	campaign.Service
+	err    error
+	called *bool
+}
+
+func (s stubCampaignService) GetCampaigns(ctx context.Context, input campaign.GetCampaignsInput) ([]campaign.Campaign, helper.Pagination, error) {
+	*s.called = true
+	return nil, helper.Pagination{}, s.err
+}
+
+func TestGetCampaignsErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantCode   int
+		wantCalled bool
+	}{
+		{"cursor rusak", "?cursor=rusak", fmt.Errorf("gagal membaca cursor: %w", campaign.ErrInvalidCursor), http.StatusUnprocessableEntity, true},
+		{"database gagal", "", errors.New("koneksi database terputus"), http.StatusInternalServerError, true},
+		{"min_goal di atas max_goal", "?min_goal=5000&max_goal=1000", nil, http.StatusUnprocessableEntity, false},
+	}
+
+	for _, tc := range tests {
+		called := false
+		router := gin.New()
+		router.GET("/campaigns", NewCampaignHandler(stubCampaignService{err: tc.err, called: &called}, "").GetCampaigns)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/campaigns"+tc.query, nil))
+
+		if recorder.Code != tc.wantCode {
+			t.Errorf("%s: status = %d, mau %d", tc.name, recorder.Code, tc.wantCode)
+		}
+		if called != tc.wantCalled {
+			t.Errorf("%s: service dipanggil = %v, mau %v", tc.name, called, tc.wantCalled)
+		}
+	}
+}
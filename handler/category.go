@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"campaignku/campaign"
+	"campaignku/helper"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Struct buat handle kategori campaign.
+type categoryHandler struct {
+	service campaign.Service // Kategori diurus sama service campaign.
+}
+
+// Fungsi buat bikin handler kategori baru.
+func NewCategoryHandler(service campaign.Service) *categoryHandler {
+	return &categoryHandler{service}
+}
+
+// Method buat dapetin semua kategori plus jumlah campaign-nya.
+func (h *categoryHandler) GetCategories(c *gin.Context) {
+	categories, err := h.service.GetCategories(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat kategori", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Daftar kategori", http.StatusOK, "success", campaign.FormatCategories(categories))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat bikin kategori baru, khusus admin.
+func (h *categoryHandler) CreateCategory(c *gin.Context) {
+	var input campaign.CategoryInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal membuat kategori", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	newCategory, err := h.service.CreateCategory(c.Request.Context(), input)
+	if err != nil {
+		respondCampaignError(c, "Gagal membuat kategori", err)
+		return
+	}
+
+	response := helper.ApiResponse("Kategori berhasil dibuat", http.StatusOK, "success", campaign.FormatCategory(newCategory))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah kategori, khusus admin.
+func (h *categoryHandler) UpdateCategory(c *gin.Context) {
+	var inputID campaign.GetCategoryInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah kategori", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input campaign.CategoryInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah kategori", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	updatedCategory, err := h.service.UpdateCategory(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCampaignError(c, "Gagal mengubah kategori", err)
+		return
+	}
+
+	response := helper.ApiResponse("Kategori berhasil diubah", http.StatusOK, "success", campaign.FormatCategory(updatedCategory))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngapus kategori, khusus admin.
+func (h *categoryHandler) DeleteCategory(c *gin.Context) {
+	var inputID campaign.GetCategoryInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menghapus kategori", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	err = h.service.DeleteCategory(c.Request.Context(), inputID)
+	if err != nil {
+		respondCampaignError(c, "Gagal menghapus kategori", err)
+		return
+	}
+
+	response := helper.ApiResponse("Kategori berhasil dihapus", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"campaignku/campaign"
+	"campaignku/comment"
+	"campaignku/helper"
+	"campaignku/user"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Struct buat handle komentar campaign.
+type commentHandler struct {
+	service comment.Service
+}
+
+// Fungsi buat bikin handler komentar baru.
+func NewCommentHandler(service comment.Service) *commentHandler {
+	return &commentHandler{service}
+}
+
+// Method buat dapetin komentar sebuah campaign per halaman, yang paling baru duluan.
+// Query string yang didukung: page dan limit.
+func (h *commentHandler) GetComments(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat komentar", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input comment.GetCommentsInput
+
+	err = c.ShouldBindQuery(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal memuat komentar", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	viewer := optionalCurrentUser(c)
+
+	comments, pagination, canModerate, err := h.service.GetComments(c.Request.Context(), inputID, input, viewer)
+	if err != nil {
+		respondCommentError(c, "Gagal memuat komentar", err)
+		return
+	}
+
+	response := helper.ApiResponseWithPagination("Daftar komentar", http.StatusOK, "success", comment.FormatComments(comments, viewerID(viewer), canModerate), pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nulis komentar atau balasan di campaign.
+func (h *commentHandler) CreateComment(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengirim komentar", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input comment.CreateCommentInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengirim komentar", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newComment, err := h.service.CreateComment(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCommentError(c, "Gagal mengirim komentar", err)
+		return
+	}
+
+	response := helper.ApiResponse("Komentar berhasil dikirim", http.StatusOK, "success", comment.FormatComment(newComment, input.User.ID, false))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah komentar. Cuma penulisnya yang boleh.
+func (h *commentHandler) UpdateComment(c *gin.Context) {
+	var inputID comment.GetCommentInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah komentar", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input comment.UpdateCommentInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah komentar", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	updatedComment, err := h.service.UpdateComment(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCommentError(c, "Gagal mengubah komentar", err)
+		return
+	}
+
+	response := helper.ApiResponse("Komentar berhasil diubah", http.StatusOK, "success", comment.FormatComment(updatedComment, input.User.ID, false))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngapus komentar. Cuma penulisnya yang boleh.
+func (h *commentHandler) DeleteComment(c *gin.Context) {
+	var inputID comment.GetCommentInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menghapus komentar", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	err = h.service.DeleteComment(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondCommentError(c, "Gagal menghapus komentar", err)
+		return
+	}
+
+	response := helper.ApiResponse("Komentar berhasil dihapus", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nyembunyiin atau nyematin komentar. Cuma pemilik campaign yang boleh.
+func (h *commentHandler) ModerateComment(c *gin.Context) {
+	var inputID comment.GetCommentInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memoderasi komentar", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input comment.ModerateCommentInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal memoderasi komentar", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	moderatedComment, err := h.service.ModerateComment(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondCommentError(c, "Gagal memoderasi komentar", err)
+		return
+	}
+
+	response := helper.ApiResponse("Komentar berhasil dimoderasi", http.StatusOK, "success", comment.FormatComment(moderatedComment, input.User.ID, true))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin riwayat perubahan komentar.
+func (h *commentHandler) GetCommentHistory(c *gin.Context) {
+	var inputID comment.GetCommentInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat riwayat komentar", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	revisions, err := h.service.GetCommentHistory(c.Request.Context(), inputID, optionalCurrentUser(c))
+	if err != nil {
+		respondCommentError(c, "Gagal memuat riwayat komentar", err)
+		return
+	}
+
+	response := helper.ApiResponse("Riwayat komentar", http.StatusOK, "success", comment.FormatCommentRevisions(revisions))
+	c.JSON(http.StatusOK, response)
+}
+
+// respondCommentError milih kode status HTTP yang pas buat error dari service komentar.
+func respondCommentError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, campaign.ErrCampaignNotFound), errors.Is(err, comment.ErrCommentNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, campaign.ErrNotOwner), errors.Is(err, comment.ErrNotAuthor):
+		code = http.StatusForbidden
+	case errors.Is(err, comment.ErrInvalidParent), errors.Is(err, comment.ErrPinReply):
+		code = http.StatusUnprocessableEntity
+	default:
+		response := helper.ApiResponse(message, code, "error", nil)
+		c.JSON(code, response)
+		return
+	}
+
+	errorMessage := gin.H{"errors": err.Error()}
+	response := helper.ApiResponse(message, code, "error", errorMessage)
+	c.JSON(code, response)
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"campaignku/campaign"
+	"campaignku/follow"
+	"campaignku/helper"
+	"campaignku/user"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Struct buat handle follow dan linimasa.
+type followHandler struct {
+	service follow.Service
+}
+
+// Fungsi buat bikin handler follow baru.
+func NewFollowHandler(service follow.Service) *followHandler {
+	return &followHandler{service}
+}
+
+// Method buat ngikutin campaign.
+func (h *followHandler) FollowCampaign(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengikuti campaign", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	followerCount, err := h.service.FollowCampaign(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondFollowError(c, "Gagal mengikuti campaign", err)
+		return
+	}
+
+	response := helper.ApiResponse("Berhasil mengikuti campaign", http.StatusOK, "success", follow.FormatFollow(true, followerCount))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat berhenti ngikutin campaign.
+func (h *followHandler) UnfollowCampaign(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal berhenti mengikuti campaign", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	followerCount, err := h.service.UnfollowCampaign(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondFollowError(c, "Gagal berhenti mengikuti campaign", err)
+		return
+	}
+
+	response := helper.ApiResponse("Berhasil berhenti mengikuti campaign", http.StatusOK, "success", follow.FormatFollow(false, followerCount))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngikutin kreator.
+func (h *followHandler) FollowCreator(c *gin.Context) {
+	var inputID follow.GetCreatorInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengikuti kreator", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	followerCount, err := h.service.FollowCreator(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondFollowError(c, "Gagal mengikuti kreator", err)
+		return
+	}
+
+	response := helper.ApiResponse("Berhasil mengikuti kreator", http.StatusOK, "success", follow.FormatFollow(true, followerCount))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat berhenti ngikutin kreator.
+func (h *followHandler) UnfollowCreator(c *gin.Context) {
+	var inputID follow.GetCreatorInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal berhenti mengikuti kreator", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	followerCount, err := h.service.UnfollowCreator(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondFollowError(c, "Gagal berhenti mengikuti kreator", err)
+		return
+	}
+
+	response := helper.ApiResponse("Berhasil berhenti mengikuti kreator", http.StatusOK, "success", follow.FormatFollow(false, followerCount))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin linimasa user yang lagi login.
+// Query string yang didukung: page dan limit.
+func (h *followHandler) GetFeed(c *gin.Context) {
+	var input follow.GetFeedInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal memuat linimasa", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	items, pagination, err := h.service.GetFeed(c.Request.Context(), input, currentUser)
+	if err != nil {
+		respondFollowError(c, "Gagal memuat linimasa", err)
+		return
+	}
+
+	response := helper.ApiResponseWithPagination("Linimasa", http.StatusOK, "success", follow.FormatFeed(items), pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// respondFollowError milih kode status HTTP yang pas buat error dari service follow.
+func respondFollowError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, campaign.ErrCampaignNotFound), errors.Is(err, follow.ErrCreatorNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, follow.ErrFollowSelf):
+		code = http.StatusUnprocessableEntity
+	default:
+		response := helper.ApiResponse(message, code, "error", nil)
+		c.JSON(code, response)
+		return
+	}
+
+	errorMessage := gin.H{"errors": err.Error()}
+	response := helper.ApiResponse(message, code, "error", errorMessage)
+	c.JSON(code, response)
+}
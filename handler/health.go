@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"campaignku/health"
+	"campaignku/helper"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Struct buat handle pemeriksaan kesehatan aplikasi.
+type healthHandler struct {
+	service health.Service
+}
+
+// Fungsi buat bikin handler kesehatan baru.
+func NewHealthHandler(service health.Service) *healthHandler {
+	return &healthHandler{service}
+}
+
+// Method buat liveness probe. Selalu 200 selama prosesnya masih bisa jawab request.
+func (h *healthHandler) Live(c *gin.Context) {
+	report := h.service.Live(c.Request.Context())
+
+	response := helper.ApiResponse("Aplikasi hidup", http.StatusOK, "success", report)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat readiness probe. Balikin 503 kalo ada pemeriksaan yang gagal, biar orchestrator
+// nggak ngirim traffic ke instance ini dulu. Hasil tiap pemeriksaan tetep dikirim buat bahan debug.
+func (h *healthHandler) Ready(c *gin.Context) {
+	report := h.service.Ready(c.Request.Context())
+
+	if report.Status != health.StatusOK {
+		response := helper.ApiResponse("Aplikasi belum siap", http.StatusServiceUnavailable, "error", report)
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	response := helper.ApiResponse("Aplikasi siap", http.StatusOK, "success", report)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin info build binary yang lagi jalan.
+func (h *healthHandler) Version(c *gin.Context) {
+	response := helper.ApiResponse("Info versi", http.StatusOK, "success", health.Build())
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"campaignku/helper"
+	"campaignku/notification"
+	"campaignku/user"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Struct buat handle notifikasi pengguna.
+type notificationHandler struct {
+	service notification.Service
+}
+
+// Fungsi buat bikin handler notifikasi baru.
+func NewNotificationHandler(service notification.Service) *notificationHandler {
+	return &notificationHandler{service}
+}
+
+// Method buat dapetin notifikasi user yang lagi login per halaman, yang paling baru duluan.
+// Query string yang didukung: unread, page, dan limit.
+func (h *notificationHandler) GetNotifications(c *gin.Context) {
+	var input notification.GetNotificationsInput
+
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal memuat notifikasi", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	notifications, pagination, err := h.service.GetNotifications(c.Request.Context(), input, currentUser.ID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat notifikasi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponseWithPagination("Daftar notifikasi", http.StatusOK, "success", notification.FormatNotifications(notifications), pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin jumlah notifikasi yang belum dibaca.
+func (h *notificationHandler) GetUnreadCount(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	unreadCount, err := h.service.CountUnread(c.Request.Context(), currentUser.ID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menghitung notifikasi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Jumlah notifikasi belum dibaca", http.StatusOK, "success", notification.FormatUnreadCount(unreadCount))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nandain satu notifikasi udah dibaca.
+func (h *notificationHandler) MarkAsRead(c *gin.Context) {
+	var inputID notification.GetNotificationInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menandai notifikasi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	readNotification, err := h.service.MarkAsRead(c.Request.Context(), inputID, currentUser.ID)
+	if err != nil {
+		respondNotificationError(c, "Gagal menandai notifikasi", err)
+		return
+	}
+
+	response := helper.ApiResponse("Notifikasi ditandai sudah dibaca", http.StatusOK, "success", notification.FormatNotification(readNotification))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nandain semua notifikasi udah dibaca. Jumlah yang belum dibaca jadi nol.
+func (h *notificationHandler) MarkAllAsRead(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	_, err := h.service.MarkAllAsRead(c.Request.Context(), currentUser.ID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menandai notifikasi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Semua notifikasi ditandai sudah dibaca", http.StatusOK, "success", notification.FormatUnreadCount(0))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin preferensi notifikasi user yang lagi login.
+func (h *notificationHandler) GetPreferences(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	preferences, err := h.service.GetPreferences(c.Request.Context(), currentUser.ID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat preferensi notifikasi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Preferensi notifikasi", http.StatusOK, "success", notification.FormatPreferences(preferences))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah preferensi notifikasi, termasuk mau dikirimin email atau enggak.
+func (h *notificationHandler) UpdatePreferences(c *gin.Context) {
+	var input notification.UpdatePreferencesInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah preferensi notifikasi", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	preferences, err := h.service.UpdatePreferences(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah preferensi notifikasi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Preferensi notifikasi berhasil diubah", http.StatusOK, "success", notification.FormatPreferences(preferences))
+	c.JSON(http.StatusOK, response)
+}
+
+// respondNotificationError milih kode status HTTP yang pas buat error dari service notifikasi.
+func respondNotificationError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, notification.ErrNotificationNotFound):
+		code = http.StatusNotFound
+	default:
+		response := helper.ApiResponse(message, code, "error", nil)
+		c.JSON(code, response)
+		return
+	}
+
+	errorMessage := gin.H{"errors": err.Error()}
+	response := helper.ApiResponse(message, code, "error", errorMessage)
+	c.JSON(code, response)
+}
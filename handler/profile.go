@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"campaignku/helper"
+	"campaignku/profile"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Struct buat handle profil publik kreator.
+type profileHandler struct {
+	service profile.Service
+}
+
+// Fungsi buat bikin handler profil baru.
+func NewProfileHandler(service profile.Service) *profileHandler {
+	return &profileHandler{service}
+}
+
+// Method buat dapetin profil publik seorang kreator.
+func (h *profileHandler) GetProfile(c *gin.Context) {
+	var input profile.GetProfileInput
+
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat profil", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	creatorProfile, err := h.service.GetProfile(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		code := http.StatusBadRequest
+		if errors.Is(err, profile.ErrProfileNotFound) {
+			code = http.StatusNotFound
+		}
+		response := helper.ApiResponse("Gagal memuat profil", code, "error", nil)
+		c.JSON(code, response)
+		return
+	}
+
+	response := helper.ApiResponse("Profil kreator", http.StatusOK, "success", profile.FormatProfile(creatorProfile))
+	c.JSON(http.StatusOK, response)
+}
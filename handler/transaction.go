@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"campaignku/campaign"
+	"campaignku/helper"
+	"campaignku/pubsub"
+	"campaignku/transaction"
+	"campaignku/user"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Jeda kirim ping ke stream biar koneksinya nggak diputus proxy karena dianggap nganggur.
+const streamKeepAlive = 15 * time.Second
+
+// Struct buat handle transaksi.
+type transactionHandler struct {
+	service    transaction.Service
+	subscriber pubsub.Subscriber // Sumber event progress pendanaan buat stream.
+}
+
+// Fungsi buat bikin handler transaksi baru.
+func NewTransactionHandler(service transaction.Service, subscriber pubsub.Subscriber) *transactionHandler {
+	return &transactionHandler{service, subscriber}
+}
+
+// Method buat dapetin riwayat transaksi user yang lagi login.
+func (h *transactionHandler) GetUserTransactions(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	transactions, err := h.service.GetUserTransactions(c.Request.Context(), currentUser.ID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat transaksi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Daftar transaksi", http.StatusOK, "success", transaction.FormatTransactions(transactions))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat bikin transaksi dukungan ke campaign.
+func (h *transactionHandler) CreateTransaction(c *gin.Context) {
+	var input transaction.CreateTransactionInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal membuat transaksi", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newTransaction, err := h.service.CreateTransaction(c.Request.Context(), input)
+	if err != nil {
+		respondTransactionError(c, "Gagal membuat transaksi", err)
+		return
+	}
+
+	response := helper.ApiResponse("Transaksi berhasil dibuat", http.StatusOK, "success", transaction.FormatTransaction(newTransaction))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat nerima notifikasi pembayaran dari Midtrans.
+func (h *transactionHandler) GetNotification(c *gin.Context) {
+	var input transaction.TransactionNotificationInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memproses notifikasi", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	err = h.service.ProcessPayment(c.Request.Context(), input)
+	if err != nil {
+		respondTransactionError(c, "Gagal memproses notifikasi", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, input)
+}
+
+// Method buat dapetin daftar pengiriman reward sebuah campaign. Cuma pemilik campaign yang boleh.
+func (h *transactionHandler) GetFulfilments(c *gin.Context) {
+	var inputID transaction.GetCampaignFulfilmentsInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat daftar pengiriman", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	fulfilments, err := h.service.GetFulfilments(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondTransactionError(c, "Gagal memuat daftar pengiriman", err)
+		return
+	}
+
+	response := helper.ApiResponse("Daftar pengiriman", http.StatusOK, "success", transaction.FormatFulfilments(fulfilments))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah status pengiriman banyak backer sekaligus. Cuma pemilik campaign yang boleh.
+func (h *transactionHandler) UpdateFulfilments(c *gin.Context) {
+	var inputID transaction.GetCampaignFulfilmentsInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah status pengiriman", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input transaction.UpdateFulfilmentsInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah status pengiriman", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	fulfilments, err := h.service.UpdateFulfilments(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondTransactionError(c, "Gagal mengubah status pengiriman", err)
+		return
+	}
+
+	response := helper.ApiResponse("Status pengiriman berhasil diubah", http.StatusOK, "success", transaction.FormatFulfilments(fulfilments))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngunduh daftar alamat pengiriman reward dalam bentuk CSV. Cuma pemilik campaign yang boleh.
+func (h *transactionHandler) ExportShippingList(c *gin.Context) {
+	var inputID transaction.GetCampaignFulfilmentsInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengunduh daftar pengiriman", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	fulfilments, err := h.service.GetFulfilments(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondTransactionError(c, "Gagal mengunduh daftar pengiriman", err)
+		return
+	}
+
+	fileName := fmt.Sprintf("campaign-%d-shipping.csv", inputID.ID)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Status(http.StatusOK)
+
+	if err := transaction.WriteShippingCSV(c.Writer, fulfilments); err != nil {
+		c.Error(err)
+	}
+}
+
+// Method buat ngalirin progress pendanaan campaign secara real-time pake Server-Sent Events.
+// Begitu nyambung, client dapet event snapshot berisi dana, jumlah backer, dan backer terbaru.
+// Setelah itu tiap ada transaksi yang lunas (atau direfund) client dapet event progress.
+func (h *transactionHandler) StreamCampaignProgress(c *gin.Context) {
+	var inputID campaign.GetCampaignDetailInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal membuka stream campaign", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	// Langganan dulu sebelum ambil snapshot, biar nggak ada event yang kelewat di antaranya.
+	events, unsubscribe := h.subscriber.Subscribe(transaction.CampaignTopic(inputID.ID))
+	defer unsubscribe()
+
+	progress, err := h.service.GetCampaignProgress(c.Request.Context(), inputID)
+	if err != nil {
+		respondTransactionError(c, "Gagal membuka stream campaign", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	// Stream jalan jauh lebih lama dari WriteTimeout server, jadi batas waktu tulisnya dimundurin tiap mau nulis.
+	// Client yang nggak kebaca lagi tetep keputus setelah dua kali keep-alive.
+	controller := http.NewResponseController(c.Writer)
+	extendDeadline := func() {
+		_ = controller.SetWriteDeadline(time.Now().Add(2 * streamKeepAlive))
+	}
+	extendDeadline()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent(transaction.EventProgressSnapshot, transaction.FormatProgress(progress))
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			extendDeadline()
+			c.SSEvent(event.Type, string(event.Payload))
+			return true
+		case <-keepAlive.C:
+			extendDeadline()
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// respondTransactionError milih kode status HTTP yang pas buat error dari service transaksi.
+func respondTransactionError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, transaction.ErrTransactionNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, transaction.ErrInvalidSignature):
+		code = http.StatusUnauthorized
+	case errors.Is(err, campaign.ErrCampaignNotFound),
+		errors.Is(err, campaign.ErrRewardNotFound),
+		errors.Is(err, user.ErrAddressNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, campaign.ErrNotOwner):
+		code = http.StatusForbidden
+	case errors.Is(err, campaign.ErrCampaignClosed),
+		errors.Is(err, campaign.ErrRewardSoldOut),
+		errors.Is(err, transaction.ErrAmountBelowReward),
+		errors.Is(err, transaction.ErrAddressRequired),
+		errors.Is(err, transaction.ErrTrackingRequired):
+		code = http.StatusUnprocessableEntity
+	default:
+		response := helper.ApiResponse(message, code, "error", nil)
+		c.JSON(code, response)
+		return
+	}
+
+	errorMessage := gin.H{"errors": err.Error()}
+	response := helper.ApiResponse(message, code, "error", errorMessage)
+	c.JSON(code, response)
+}
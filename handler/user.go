@@ -5,6 +5,7 @@ import (
 	"campaignku/auth"
 	"campaignku/helper"
 	"campaignku/user"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -15,11 +16,12 @@ import (
 type usersHandler struct {
 	userService user.Service // Layanan pengguna.
 	authService auth.Service // Layanan otentikasi.
+	imageDir    string       // Folder buat nyimpen avatar yang diunggah.
 }
 
 // NewUserHandler membuat objek usersHandler baru dengan layanan pengguna dan otentikasi yang diperlukan.
-func NewUserHandler(userService user.Service, authService auth.Service) *usersHandler {
-	return &usersHandler{userService, authService} // Return instance usersHandler.
+func NewUserHandler(userService user.Service, authService auth.Service, imageDir string) *usersHandler {
+	return &usersHandler{userService, authService, imageDir} // Return instance usersHandler.
 }
 
 // RegisterUser menangani permintaan pendaftaran pengguna baru.
@@ -30,6 +32,7 @@ func (h *usersHandler) RegisterUser(c *gin.Context) {
 	err := c.ShouldBindJSON(&input)
 	if err != nil {
 		// Handle error validasi.
+		c.Error(err)
 		errors := helper.FormatValidationError(err)
 		errorMessage := gin.H{"errors": errors}
 		response := helper.ApiResponse("Gagal mendaftarkan akun", http.StatusUnprocessableEntity, "error", errorMessage)
@@ -38,9 +41,10 @@ func (h *usersHandler) RegisterUser(c *gin.Context) {
 	}
 
 	// Daftarkan pengguna menggunakan layanan.
-	newUser, err := h.userService.RegisterUser(input)
+	newUser, err := h.userService.RegisterUser(c.Request.Context(), input)
 	if err != nil {
 		// Handle error saat registrasi.
+		c.Error(err)
 		response := helper.ApiResponse("Gagal mendaftarkan akun", http.StatusBadRequest, "success", nil)
 		c.JSON(http.StatusBadRequest, response)
 		return
@@ -49,6 +53,7 @@ func (h *usersHandler) RegisterUser(c *gin.Context) {
 	// Generate token JWT setelah registrasi sukses.
 	token, err := h.authService.GenerateToken(newUser.ID)
 	if err != nil {
+		c.Error(err)
 		response := helper.ApiResponse("Gagal mendaftarkan akun", http.StatusBadRequest, "success", nil)
 		c.JSON(http.StatusBadRequest, response)
 		return
@@ -68,6 +73,7 @@ func (h *usersHandler) Login(c *gin.Context) {
 	err := c.ShouldBindJSON(&input)
 	if err != nil {
 		// Handle error validasi.
+		c.Error(err)
 		errors := helper.FormatValidationError(err)
 		errorMessage := gin.H{"errors": errors}
 		response := helper.ApiResponse("Login gagal", http.StatusUnprocessableEntity, "error", errorMessage)
@@ -76,9 +82,10 @@ func (h *usersHandler) Login(c *gin.Context) {
 	}
 
 	// Lakukan login menggunakan layanan.
-	loggedinUser, err := h.userService.Login(input)
+	loggedinUser, err := h.userService.Login(c.Request.Context(), input)
 	if err != nil {
 		// Handle error saat login.
+		c.Error(err)
 		errorMessage := gin.H{"errors": err.Error()}
 		response := helper.ApiResponse("Login gagal", http.StatusUnprocessableEntity, "error", errorMessage)
 		c.JSON(http.StatusUnprocessableEntity, response)
@@ -88,6 +95,7 @@ func (h *usersHandler) Login(c *gin.Context) {
 	// Generate token JWT setelah login sukses.
 	token, err := h.authService.GenerateToken(loggedinUser.ID)
 	if err != nil {
+		c.Error(err)
 		response := helper.ApiResponse("Login gagal", http.StatusBadRequest, "success", nil)
 		c.JSON(http.StatusBadRequest, response)
 		return
@@ -107,6 +115,7 @@ func (h *usersHandler) CheckEmailAvailability(c *gin.Context) {
 	err := c.ShouldBindJSON(&input)
 	if err != nil {
 		// Handle error validasi.
+		c.Error(err)
 		errors := helper.FormatValidationError(err)
 		errorMessage := gin.H{"errors": errors}
 		response := helper.ApiResponse("Pengecekan email gagal", http.StatusUnprocessableEntity, "error", errorMessage)
@@ -115,9 +124,10 @@ func (h *usersHandler) CheckEmailAvailability(c *gin.Context) {
 	}
 
 	// Periksa ketersediaan alamat email menggunakan layanan.
-	isEmailAvailable, err := h.userService.IsEmailAvailable(input)
+	isEmailAvailable, err := h.userService.IsEmailAvailable(c.Request.Context(), input)
 	if err != nil {
 		// Handle error saat cek ketersediaan email.
+		c.Error(err)
 		errorMessage := gin.H{"errors": "Server error"}
 		response := helper.ApiResponse("Pengecekan email gagal", http.StatusUnprocessableEntity, "error", errorMessage)
 		c.JSON(http.StatusUnprocessableEntity, response)
@@ -142,6 +152,7 @@ func (h *usersHandler) UploadAvatar(c *gin.Context) {
 	file, err := c.FormFile("avatar")
 	if err != nil {
 		// Handle error saat mengambil file.
+		c.Error(err)
 		data := gin.H{"is_uploaded": false}
 		response := helper.ApiResponse("Gagal mengunggah gambar avatar", http.StatusBadRequest, "error", data)
 		c.JSON(http.StatusBadRequest, response)
@@ -153,12 +164,13 @@ func (h *usersHandler) UploadAvatar(c *gin.Context) {
 	userID := currentUser.ID
 
 	// Tentukan path penyimpanan file.
-	path := fmt.Sprintf("images/%d-%s", userID, file.Filename)
+	path := fmt.Sprintf("%s/%d-%s", h.imageDir, userID, file.Filename)
 
 	// Simpan file yang diunggah.
 	err = c.SaveUploadedFile(file, path)
 	if err != nil {
 		// Handle error saat menyimpan file.
+		c.Error(err)
 		data := gin.H{"is_uploaded": false}
 		response := helper.ApiResponse("Gagal mengunggah gambar avatar", http.StatusBadRequest, "error", data)
 		c.JSON(http.StatusBadRequest, response)
@@ -166,9 +178,10 @@ func (h *usersHandler) UploadAvatar(c *gin.Context) {
 	}
 
 	// Update path avatar di database.
-	_, err = h.userService.SaveAvatar(userID, path)
+	_, err = h.userService.SaveAvatar(c.Request.Context(), userID, path)
 	if err != nil {
 		// Handle error saat update database.
+		c.Error(err)
 		data := gin.H{"is_uploaded": false}
 		response := helper.ApiResponse("Gagal mengunggah gambar avatar", http.StatusBadRequest, "error", data)
 		c.JSON(http.StatusBadRequest, response)
@@ -180,3 +193,124 @@ func (h *usersHandler) UploadAvatar(c *gin.Context) {
 	response := helper.ApiResponse("Avatar berhasil diunggah", http.StatusOK, "success", data)
 	c.JSON(http.StatusOK, response)
 }
+
+// UpdateProfile menangani permintaan perubahan profil publik pengguna yang lagi login.
+func (h *usersHandler) UpdateProfile(c *gin.Context) {
+	var input user.UpdateProfileInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah profil", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	_, err = h.userService.UpdateProfile(c.Request.Context(), input)
+	if err != nil {
+		respondUserError(c, "Gagal mengubah profil", err)
+		return
+	}
+
+	response := helper.ApiResponse("Profil berhasil diubah", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// RequestPasswordReset menangani permintaan tautan ganti password.
+// Responsnya selalu sama, baik email-nya terdaftar maupun enggak.
+func (h *usersHandler) RequestPasswordReset(c *gin.Context) {
+	var input user.RequestPasswordResetInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal meminta ganti password", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	err = h.userService.RequestPasswordReset(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal meminta ganti password", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Kalau email terdaftar, tautan ganti password sudah dikirim", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// ResetPassword menangani penggantian password pake token dari email.
+func (h *usersHandler) ResetPassword(c *gin.Context) {
+	var input user.ResetPasswordInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengganti password", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	err = h.userService.ResetPassword(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, user.ErrInvalidResetToken) {
+			errorMessage := gin.H{"errors": err.Error()}
+			response := helper.ApiResponse("Gagal mengganti password", http.StatusBadRequest, "error", errorMessage)
+			c.JSON(http.StatusBadRequest, response)
+			return
+		}
+		response := helper.ApiResponse("Gagal mengganti password", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Password berhasil diganti", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// respondUserError milih kode status HTTP yang pas buat error dari service pengguna.
+// Error lain yang nggak dikenal (misal error database) dianggap error server dan nggak ditampilin detailnya ke client.
+func respondUserError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	if errors.Is(err, user.ErrUserNotFound) {
+		errorMessage := gin.H{"errors": err.Error()}
+		response := helper.ApiResponse(message, http.StatusNotFound, "error", errorMessage)
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	response := helper.ApiResponse(message, http.StatusInternalServerError, "error", nil)
+	c.JSON(http.StatusInternalServerError, response)
+}
+
+// optionalCurrentUser ngambil user yang lagi login di endpoint yang pake optionalAuthMiddleware.
+// Balikin nil kalo yang buka belum login.
+func optionalCurrentUser(c *gin.Context) *user.User {
+	currentUser, ok := c.Get("currentUser")
+	if !ok {
+		return nil
+	}
+
+	loggedInUser := currentUser.(user.User)
+	return &loggedInUser
+}
+
+// viewerID ngambil ID user yang lagi login, 0 kalo belum login.
+func viewerID(viewer *user.User) int {
+	if viewer == nil {
+		return 0
+	}
+	return viewer.ID
+}
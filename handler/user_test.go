@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"campaignku/user"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// failingUserRepository adalah repository pengguna yang semua query-nya gagal, kayak waktu database lagi mati.
+// Method yang nggak ditimpa bakal panic karena Repository-nya nil, jadi ketahuan kalo ada yang kepanggil tanpa sengaja.
+type failingUserRepository struct {
+	user.Repository
+	err error
+}
+
+func (r failingUserRepository) FindByID(ctx context.Context, ID int) (user.User, error) {
+	return user.User{}, r.err
+}
+
+func (r failingUserRepository) Update(ctx context.Context, u user.User) (user.User, error) {
+	return u, r.err
+}
+
+func TestUpdateProfileDatabaseError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repository := failingUserRepository{err: errors.New("koneksi database terputus")}
+	handler := NewUserHandler(user.NewService(repository, nil, nil), nil, "")
+
+	router := gin.New()
+	router.PUT("/profile", func(c *gin.Context) {
+		c.Set("currentUser", user.User{ID: 1, Name: "Budi"})
+	}, handler.UpdateProfile)
+
+	request := httptest.NewRequest(http.MethodPut, "/profile", strings.NewReader(`{"name":"Budi","occupation":"Guru"}`))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, mau %d kalo database-nya gagal", recorder.Code, http.StatusInternalServerError)
+	}
+	if strings.Contains(recorder.Body.String(), "koneksi database") {
+		t.Errorf("pesan error database ikut kekirim ke client: %s", recorder.Body.String())
+	}
+}
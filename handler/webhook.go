@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"campaignku/helper"
+	"campaignku/user"
+	"campaignku/webhook"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Struct buat handle langganan webhook.
+type webhookHandler struct {
+	service webhook.Service
+}
+
+// Fungsi buat bikin handler webhook baru.
+func NewWebhookHandler(service webhook.Service) *webhookHandler {
+	return &webhookHandler{service}
+}
+
+// Method buat dapetin semua langganan webhook milik user yang lagi login.
+func (h *webhookHandler) GetSubscriptions(c *gin.Context) {
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	subscriptions, err := h.service.GetSubscriptions(c.Request.Context(), currentUser.ID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat webhook", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := helper.ApiResponse("Daftar webhook", http.StatusOK, "success", webhook.FormatSubscriptions(subscriptions))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat bikin langganan webhook baru. Secret-nya cuma ditampilin di respons ini.
+func (h *webhookHandler) CreateSubscription(c *gin.Context) {
+	var input webhook.SubscriptionInput
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal membuat webhook", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	newSubscription, err := h.service.CreateSubscription(c.Request.Context(), input)
+	if err != nil {
+		respondWebhookError(c, "Gagal membuat webhook", err)
+		return
+	}
+
+	response := helper.ApiResponse("Webhook berhasil dibuat", http.StatusOK, "success", webhook.FormatNewSubscription(newSubscription))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngubah langganan webhook.
+func (h *webhookHandler) UpdateSubscription(c *gin.Context) {
+	var inputID webhook.GetSubscriptionInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengubah webhook", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	var input webhook.SubscriptionInput
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		c.Error(err)
+		errors := helper.FormatValidationError(err)
+		errorMessage := gin.H{"errors": errors}
+		response := helper.ApiResponse("Gagal mengubah webhook", http.StatusUnprocessableEntity, "error", errorMessage)
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	input.User = c.MustGet("currentUser").(user.User)
+
+	updatedSubscription, err := h.service.UpdateSubscription(c.Request.Context(), inputID, input)
+	if err != nil {
+		respondWebhookError(c, "Gagal mengubah webhook", err)
+		return
+	}
+
+	response := helper.ApiResponse("Webhook berhasil diubah", http.StatusOK, "success", webhook.FormatSubscription(updatedSubscription))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngapus langganan webhook.
+func (h *webhookHandler) DeleteSubscription(c *gin.Context) {
+	var inputID webhook.GetSubscriptionInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal menghapus webhook", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	err = h.service.DeleteSubscription(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondWebhookError(c, "Gagal menghapus webhook", err)
+		return
+	}
+
+	response := helper.ApiResponse("Webhook berhasil dihapus", http.StatusOK, "success", nil)
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat dapetin log pengiriman terbaru sebuah langganan webhook.
+func (h *webhookHandler) GetDeliveries(c *gin.Context) {
+	var inputID webhook.GetSubscriptionInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal memuat log webhook", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondWebhookError(c, "Gagal memuat log webhook", err)
+		return
+	}
+
+	response := helper.ApiResponse("Log pengiriman webhook", http.StatusOK, "success", webhook.FormatDeliveries(deliveries))
+	c.JSON(http.StatusOK, response)
+}
+
+// Method buat ngirim event percobaan ke sebuah langganan webhook.
+// Hasil pengirimannya (termasuk kalo penerimanya gagal) ada di data respons.
+func (h *webhookHandler) SendTestEvent(c *gin.Context) {
+	var inputID webhook.GetSubscriptionInput
+
+	err := c.ShouldBindUri(&inputID)
+	if err != nil {
+		c.Error(err)
+		response := helper.ApiResponse("Gagal mengirim event percobaan", http.StatusBadRequest, "error", nil)
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	currentUser := c.MustGet("currentUser").(user.User)
+
+	delivery, err := h.service.SendTestEvent(c.Request.Context(), inputID, currentUser)
+	if err != nil {
+		respondWebhookError(c, "Gagal mengirim event percobaan", err)
+		return
+	}
+
+	response := helper.ApiResponse("Event percobaan dikirim", http.StatusOK, "success", webhook.FormatDelivery(delivery))
+	c.JSON(http.StatusOK, response)
+}
+
+// respondWebhookError milih kode status HTTP yang pas buat error dari service webhook.
+func respondWebhookError(c *gin.Context, message string, err error) {
+	c.Error(err)
+
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, webhook.ErrSubscriptionNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, webhook.ErrUnsafeURL):
+		code = http.StatusUnprocessableEntity
+	default:
+		response := helper.ApiResponse(message, code, "error", nil)
+		c.JSON(code, response)
+		return
+	}
+
+	errorMessage := gin.H{"errors": err.Error()}
+	response := helper.ApiResponse(message, code, "error", errorMessage)
+	c.JSON(code, response)
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Info build yang ditanam waktu compile, misal:
+//
+//	go build -ldflags "-X campaignku/health.Commit=$(git rev-parse HEAD) -X campaignku/health.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Kalo nggak diisi, diambil dari info VCS yang otomatis ditanam go build (kalo dibuild dari dalam repo git),
+// dan BuildTime-nya pake waktu commit-nya.
+var (
+	Commit    string
+	BuildTime string
+)
+
+// BuildInfo adalah info versi binary yang lagi jalan.
+type BuildInfo struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified"` // Dibuild dari working tree yang ada perubahan belum di-commit.
+}
+
+// Build dapetin info versi binary yang lagi jalan.
+func Build() BuildInfo {
+	info := BuildInfo{
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}
@@ -0,0 +1,62 @@
+package health
+
+import (
+	"campaignku/migration"
+	"context"
+	"fmt"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// DatabaseCheck ngecek database bisa dihubungin pake ping.
+func DatabaseCheck(db *gorm.DB) Check {
+	return Check{
+		Name: "database",
+		Run: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// MigrationCheck ngecek semua migrasi yang ditanam di binary udah diterapin ke database.
+// Versi database yang lebih baru dari binary tetep dianggap siap, misal pas rollback deploy.
+func MigrationCheck(db *gorm.DB) Check {
+	return Check{
+		Name: "migrations",
+		Run: func(ctx context.Context) error {
+			migrator, err := migration.NewMigrator(db.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+
+			pending, err := migrator.Pending()
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d migrasi belum diterapin, mulai dari %04d_%s", len(pending), pending[0].Version, pending[0].Name)
+			}
+			return nil
+		},
+	}
+}
+
+// StorageCheck ngecek folder penyimpanan ada dan bisa ditulis, caranya bikin file sementara terus dihapus lagi.
+func StorageCheck(dir string) Check {
+	return Check{
+		Name: "storage",
+		Run: func(ctx context.Context) error {
+			file, err := os.CreateTemp(dir, ".healthcheck-*")
+			if err != nil {
+				return err
+			}
+			file.Close()
+			return os.Remove(file.Name())
+		},
+	}
+}
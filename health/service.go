@@ -0,0 +1,103 @@
+// Package health menyediakan pemeriksaan kesehatan aplikasi buat orchestrator (misal Kubernetes),
+// yaitu liveness (prosesnya masih hidup), readiness (siap nerima traffic), dan info build.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status hasil pemeriksaan.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// checkTimeout adalah batas waktu satu pemeriksaan. Pemeriksaan yang lewat batas dianggap gagal.
+const checkTimeout = 3 * time.Second
+
+// Check adalah satu pemeriksaan kesiapan, misal ping database. Run balikin error kalo ada yang nggak beres.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result adalah hasil satu pemeriksaan lengkap sama lama jalannya.
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report adalah gabungan hasil semua pemeriksaan. Status-nya "ok" kalo semua pemeriksaannya lolos.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks,omitempty"`
+}
+
+// Service adalah interface buat ngecek kesehatan aplikasi.
+type Service interface {
+	Live(ctx context.Context) Report  // Fungsi buat ngecek prosesnya masih hidup, nggak nyentuh layanan luar.
+	Ready(ctx context.Context) Report // Fungsi buat ngecek aplikasinya siap nerima traffic.
+}
+
+// service adalah implementasi Service yang jalanin semua pemeriksaan barengan.
+type service struct {
+	checks []Check
+}
+
+// NewService buat instance baru service dengan daftar pemeriksaan kesiapan yang diberikan.
+func NewService(checks ...Check) *service {
+	return &service{checks}
+}
+
+// Live selalu balikin "ok". Kalo prosesnya macet, request ini juga nggak bakal kejawab.
+func (s *service) Live(ctx context.Context) Report {
+	return Report{Status: StatusOK}
+}
+
+// Ready jalanin semua pemeriksaan barengan, masing-masing dibatesin checkTimeout.
+// Urutan hasilnya sama kayak urutan pemeriksaan waktu service dibikin.
+func (s *service) Ready(ctx context.Context) Report {
+	results := make([]Result, len(s.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// run jalanin satu pemeriksaan sambil ngukur lamanya.
+func run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+
+	result := Result{
+		Name:      check.Name,
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
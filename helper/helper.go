@@ -4,8 +4,9 @@ import "github.com/go-playground/validator/v10"
 
 // Response adalah struktur data yang digunakan untuk mengembalikan respons API standar.
 type Response struct {
-	Meta Meta        `json:"meta"` // Bagian meta dari respons, berisi info umum seperti pesan, kode, dan status.
-	Data interface{} `json:"data"` // Bagian data dari respons, bisa berisi apa saja.
+	Meta       Meta        `json:"meta"`                 // Bagian meta dari respons, berisi info umum seperti pesan, kode, dan status.
+	Pagination *Pagination `json:"pagination,omitempty"` // Info paginasi, cuma diisi kalo datanya berupa daftar yang dipotong per halaman.
+	Data       interface{} `json:"data"`                 // Bagian data dari respons, bisa berisi apa saja.
 }
 
 // Meta adalah struktur data yang menyimpan informasi meta-data untuk respons API.
@@ -15,6 +16,15 @@ type Meta struct {
 	Status  string `json:"status"`  // Status operasi, biasanya "success" atau "error".
 }
 
+// Pagination adalah struktur data yang menyimpan informasi paginasi untuk respons API berupa daftar.
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`        // Halaman sekarang, kosong kalo pake cursor.
+	Limit      int    `json:"limit"`                 // Jumlah data maksimal per halaman.
+	Total      int64  `json:"total"`                 // Total data yang cocok sama filter.
+	NextCursor string `json:"next_cursor,omitempty"` // Cursor buat ambil halaman berikutnya.
+	HasMore    bool   `json:"has_more"`              // Masih ada data setelah halaman ini apa enggak.
+}
+
 // ApiResponse adalah fungsi yang menghasilkan instance Response berdasarkan parameter yang diberikan.
 func ApiResponse(message string, code int, status string, data interface{}) Response {
 	meta := Meta{
@@ -31,12 +41,26 @@ func ApiResponse(message string, code int, status string, data interface{}) Resp
 	return response // Kembalikan respons yang sudah dibuat.
 }
 
+// ApiResponseWithPagination sama kayak ApiResponse, tapi sekalian nempelin info paginasi.
+func ApiResponseWithPagination(message string, code int, status string, data interface{}, pagination Pagination) Response {
+	response := ApiResponse(message, code, status, data)
+	response.Pagination = &pagination // Tempel info paginasi di samping Meta.
+
+	return response
+}
+
 // FormatValidationError adalah fungsi yang mengonversi error validasi ke dalam bentuk slice string.
 func FormatValidationError(err error) []string {
 	var errors []string // Siapin slice untuk tampung pesan error.
 
+	// Kalo errornya bukan error validasi (misal JSON rusak), balikin pesan aslinya aja.
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+
 	// Loop melalui setiap error validasi.
-	for _, e := range err.(validator.ValidationErrors) {
+	for _, e := range validationErrors {
 		errors = append(errors, e.Error()) // Tambahkan pesan error ke slice.
 	}
 
@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold adalah batas lama query yang dianggap lambat dan dicatat sebagai peringatan.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger adalah logger GORM yang nulis lewat slog, jadi log query ikut bawa request ID dari context-nya.
+type gormLogger struct {
+	logger *slog.Logger
+}
+
+// NewGormLogger bikin logger GORM dari logger aplikasi. Query yang gagal dicatat sebagai error,
+// query lambat sebagai peringatan, dan semua query di level debug. Data yang nggak ketemu bukan dianggap error.
+func NewGormLogger(logger *slog.Logger) gormlogger.Interface {
+	return &gormLogger{logger}
+}
+
+// LogMode nggak ngubah apa-apa, level log-nya ngikutin logger aplikasi.
+func (l *gormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+// Info nyatet pesan info dari GORM.
+func (l *gormLogger) Info(ctx context.Context, message string, data ...interface{}) {
+	l.logger.InfoContext(ctx, fmt.Sprintf(message, data...))
+}
+
+// Warn nyatet peringatan dari GORM.
+func (l *gormLogger) Warn(ctx context.Context, message string, data ...interface{}) {
+	l.logger.WarnContext(ctx, fmt.Sprintf(message, data...))
+}
+
+// Error nyatet error dari GORM.
+func (l *gormLogger) Error(ctx context.Context, message string, data ...interface{}) {
+	l.logger.ErrorContext(ctx, fmt.Sprintf(message, data...))
+}
+
+// Trace nyatet satu query setelah selesai dijalanin.
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+
+	var level slog.Level
+	var message string
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, message = slog.LevelError, "query database gagal"
+	case elapsed > slowQueryThreshold:
+		level, message = slog.LevelWarn, "query database lambat"
+	default:
+		level, message = slog.LevelDebug, "query database"
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("elapsed_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if level == slog.LevelError {
+		// Pesan error dari database bisa bawa nilai baris yang ditolak, misal email di "Duplicate entry".
+		attrs = append(attrs, slog.String("error", redactText(err.Error())))
+	}
+	l.logger.LogAttrs(ctx, level, message, attrs...)
+}
+
+// ParamsFilter ngebuang nilai parameter query dari log, jadi yang kecatat cuma SQL-nya pake tanda "?".
+// Nilainya sering berisi data pribadi (email, hash password, token), jadi mending nggak ikut ditulis.
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
@@ -0,0 +1,87 @@
+// Package logging nyediain log terstruktur pake log/slog: logger JSON (atau teks), request ID yang ikut
+// kecatat di tiap log selama request-nya jalan, dan penyamaran data pribadi (email, password, token) sebelum ditulis.
+package logging
+
+import (
+	"campaignku/config"
+	"context"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDKey adalah kunci buat nyimpen request ID di context.
+type requestIDKey struct{}
+
+// WithRequestID nempelin request ID ke context. Semua log yang ditulis pake context ini otomatis bawa request ID-nya.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID dapetin request ID dari context. Balikin string kosong kalo nggak ada.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// New bikin logger sesuai konfigurasi yang nulis ke w. Level dan format-nya udah divalidasi di package config.
+func New(cfg config.LogConfig, w io.Writer) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+
+	return slog.New(contextHandler{handler})
+}
+
+// contextHandler nambahin request ID dan ID trace dari context ke tiap log sebelum diterusin ke handler aslinya,
+// jadi log bisa dicocokin sama trace-nya.
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle nambahin request ID dan ID trace (kalo ada) terus nulis log-nya.
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs bikin handler baru dengan atribut tambahan, tetep lewat contextHandler.
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup bikin handler baru dengan grup atribut, tetep lewat contextHandler.
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// redactAttr nyamarin atribut log sebelum ditulis. Atribut yang namanya kayak password atau token
+// isinya diganti, alamat email di teks mana pun disamarin.
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+	if attr.Value.Kind() == slog.KindString {
+		return slog.String(attr.Key, MaskEmails(attr.Value.String()))
+	}
+	if err, ok := attr.Value.Any().(error); ok {
+		return slog.String(attr.Key, MaskEmails(err.Error()))
+	}
+	return attr
+}
@@ -0,0 +1,146 @@
+package logging
+
+import (
+	"bytes"
+	"campaignku/helper"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader adalah header buat nerima dan ngirim balik request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// maxLoggedBody adalah ukuran maksimal body request yang ikut dicatat.
+const maxLoggedBody = 4 << 10
+
+// Request ID dari client cuma dipake kalo bentuknya wajar, biar nggak bisa dipake buat nyisipin isi aneh ke log.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestIDMiddleware ngasih tiap request sebuah ID. ID dari header X-Request-ID dipake kalo ada (misal dari load balancer),
+// kalo nggak ada dibikinin baru. ID-nya dikirim balik di respons dan ditempel ke context request.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// AccessLogMiddleware nyatet tiap request setelah selesai, lengkap sama error yang ditempel handler lewat c.Error.
+// Request yang gagal (status 4xx/5xx) ikut nyatet body-nya, di level debug semua body dicatat. Body dan error-nya disamarin dulu,
+// soalnya error database kayak "Duplicate entry" bisa bawa alamat email.
+func AccessLogMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		body := captureBody(c.Request)
+
+		c.Next()
+
+		ctx := c.Request.Context()
+		status := c.Writer.Status()
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		if !logger.Enabled(ctx, level) {
+			return
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if c.Request.URL.RawQuery != "" {
+			attrs = append(attrs, slog.String("query", RedactQuery(c.Request.URL.RawQuery)))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", redactText(strings.Join(c.Errors.Errors(), "; "))))
+		}
+		if body != nil && (status >= http.StatusBadRequest || logger.Enabled(ctx, slog.LevelDebug)) {
+			if logged := RedactBody(c.ContentType(), body); logged != "" {
+				attrs = append(attrs, slog.String("body", logged))
+			}
+		}
+
+		logger.LogAttrs(ctx, level, "request", attrs...)
+	}
+}
+
+// RecoveryMiddleware nangkep panic di handler, nyatet stack trace-nya, terus balikin 500 ke client.
+// Harus dipasang setelah AccessLogMiddleware biar status 500-nya ikut kecatat.
+func RecoveryMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// Panic ini sengaja dipake buat mutus koneksi, jadi diterusin aja ke net/http.
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			logger.ErrorContext(c.Request.Context(), "panic waktu memproses request",
+				slog.Any("panic", recovered),
+				slog.String("stack", string(debug.Stack())),
+			)
+
+			response := helper.ApiResponse("Terjadi kesalahan di server", http.StatusInternalServerError, "error", nil)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		}()
+
+		c.Next()
+	}
+}
+
+// captureBody baca sebagian awal body request buat dicatat, terus balikin body-nya utuh buat handler.
+// Cuma body JSON dan form yang dibaca, body lain (misal unggahan file) dilewatin.
+func captureBody(request *http.Request) []byte {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil
+	}
+	if !loggableBody(request.Header.Get("Content-Type")) {
+		return nil
+	}
+
+	head, _ := io.ReadAll(io.LimitReader(request.Body, maxLoggedBody))
+	request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), request.Body), request.Body}
+
+	return head
+}
+
+// newRequestID bikin request ID acak.
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"encoding/json"
+	"mime"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted adalah pengganti isi yang disembunyiin dari log.
+const redacted = "[REDACTED]"
+
+// Potongan nama field yang isinya rahasia, misal password, password_confirmation, token, atau signature_key.
+var sensitiveKeyParts = []string{"password", "token", "secret", "authorization", "cookie", "signature"}
+
+var (
+	emailPattern     = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+	jsonFieldPattern = regexp.MustCompile(`"([^"\\]+)"\s*:\s*"(?:[^"\\]|\\.)*"?`)
+)
+
+// isSensitiveKey ngecek nama field termasuk rahasia apa enggak. Field yang berakhiran "key" (misal server_key) juga dianggap rahasia.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return strings.HasSuffix(key, "key")
+}
+
+// MaskEmails nyamarin semua alamat email di teks, cuma huruf pertama dan domainnya yang disisain.
+// Misal "budi@campaignku.id" jadi "b***@campaignku.id".
+func MaskEmails(text string) string {
+	if !strings.Contains(text, "@") {
+		return text
+	}
+	return emailPattern.ReplaceAllString(text, "$1***@$2")
+}
+
+// RedactBody nyamarin isi body request sebelum dicatat. Cuma body JSON dan form yang dicatat,
+// body lain (misal unggahan file) balikin string kosong. Body yang kepotong atau rusak tetep disamarin sebisanya.
+func RedactBody(contentType string, body []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "application/json":
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return redactText(string(body))
+		}
+		encoded, err := json.Marshal(redactJSON(value))
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	case "application/x-www-form-urlencoded":
+		return RedactQuery(string(body))
+	default:
+		return ""
+	}
+}
+
+// loggableBody ngecek body dengan content type tertentu boleh dicatat apa enggak.
+func loggableBody(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || mediaType == "application/x-www-form-urlencoded"
+}
+
+// RedactQuery nyamarin nilai rahasia dan alamat email di query string.
+func RedactQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return redactText(rawQuery)
+	}
+
+	for key, items := range values {
+		for i, item := range items {
+			if isSensitiveKey(key) {
+				items[i] = redacted
+			} else {
+				items[i] = MaskEmails(item)
+			}
+		}
+	}
+	return values.Encode()
+}
+
+// redactJSON nyamarin isi JSON yang udah di-decode, termasuk object dan array di dalemnya.
+func redactJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if isSensitiveKey(key) {
+				value[key] = redacted
+			} else {
+				value[key] = redactJSON(item)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactJSON(item)
+		}
+		return value
+	case string:
+		return MaskEmails(value)
+	default:
+		return value
+	}
+}
+
+// redactText nyamarin teks yang bentuknya mirip JSON tapi nggak bisa di-decode, misal karena kepotong.
+func redactText(text string) string {
+	text = jsonFieldPattern.ReplaceAllStringFunc(text, func(field string) string {
+		key := jsonFieldPattern.FindStringSubmatch(field)[1]
+		if isSensitiveKey(key) {
+			return `"` + key + `":"` + redacted + `"`
+		}
+		return field
+	})
+	return MaskEmails(text)
+}
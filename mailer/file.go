@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileDriver nulis tiap email jadi file .eml di satu folder, buat ngecek email waktu development tanpa server SMTP.
+type fileDriver struct {
+	dir  string
+	from string
+}
+
+// NewFileDriver bikin driver yang nulis email ke folder dir. Folder-nya dibikin kalo belum ada.
+func NewFileDriver(dir string, from string) *fileDriver {
+	return &fileDriver{dir, from}
+}
+
+// Send nulis satu email ke file. Nama file-nya diawali waktu kirim biar gampang diurutin.
+func (d *fileDriver) Send(message Message) error {
+	now := time.Now()
+
+	body, err := buildMIME(d.from, message, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(message.To.Email)
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405.000000000"), recipient)
+	return os.WriteFile(filepath.Join(d.dir, name), body, 0o644)
+}
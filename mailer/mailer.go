@@ -0,0 +1,72 @@
+// Package mailer menyediakan pengiriman email transaksional, lengkap sama template dan antrean kirimnya.
+package mailer
+
+// Nama template email yang tersedia.
+const (
+	TemplateWelcome        = "welcome"         // Sambutan buat pengguna yang baru daftar.
+	TemplateBackingReceipt = "backing_receipt" // Bukti dukungan yang udah lunas dibayar.
+	TemplateCampaignFunded = "campaign_funded" // Kabar campaign udah capai target.
+	TemplatePasswordReset  = "password_reset"  // Tautan buat ganti password.
+)
+
+// Bahasa email yang didukung. Pengguna yang belum milih bahasa dapet email berbahasa Indonesia.
+const (
+	LocaleID      = "id"
+	LocaleEN      = "en"
+	DefaultLocale = LocaleID
+)
+
+// Locales adalah semua bahasa email yang didukung.
+var Locales = []string{LocaleID, LocaleEN}
+
+// Recipient adalah penerima email.
+type Recipient struct {
+	Email  string
+	Name   string
+	Locale string // Kosong artinya pake DefaultLocale.
+}
+
+// Message adalah satu email yang siap dikirim, isinya ada versi HTML dan teks biasa.
+type Message struct {
+	To      Recipient
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Driver adalah cara email benar-benar dikirim, misal lewat SMTP atau ditulis ke folder.
+type Driver interface {
+	Send(message Message) error
+}
+
+// WelcomeData adalah data buat template TemplateWelcome.
+type WelcomeData struct {
+	Name string
+}
+
+// BackingReceiptData adalah data buat template TemplateBackingReceipt.
+type BackingReceiptData struct {
+	Name         string
+	CampaignName string
+	CampaignPath string
+	Code         string
+	Amount       int
+	RewardTitle  string // Kosong kalo backer nggak milih reward.
+}
+
+// CampaignFundedData adalah data buat template TemplateCampaignFunded.
+type CampaignFundedData struct {
+	Name          string
+	CampaignName  string
+	CampaignPath  string
+	CurrentAmount int
+	GoalAmount    int
+	IsOwner       bool // true kalo penerimanya pemilik campaign, bukan backer.
+}
+
+// PasswordResetData adalah data buat template TemplatePasswordReset.
+type PasswordResetData struct {
+	Name             string
+	ResetPath        string
+	ExpiresInMinutes int
+}
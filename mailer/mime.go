@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"time"
+)
+
+// buildMIME nyusun email jadi format MIME multipart/alternative, isinya versi teks biasa lalu versi HTML.
+func buildMIME(from string, message Message, now time.Time) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	to := mail.Address{Name: message.To.Name, Address: message.To.Email}
+	messageID, err := newMessageID()
+	if err != nil {
+		return nil, err
+	}
+
+	body := multipart.NewWriter(&buffer)
+	headers := []string{
+		"From: " + from,
+		"To: " + to.String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date: " + now.Format(time.RFC1123Z),
+		"Message-ID: " + messageID,
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + body.Boundary(),
+	}
+	for _, header := range headers {
+		buffer.WriteString(header + "\r\n")
+	}
+	buffer.WriteString("\r\n")
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	}
+	for _, part := range parts {
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// newMessageID bikin Message-ID acak buat header email.
+func newMessageID() (string, error) {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<%s@campaignku>", hex.EncodeToString(buffer)), nil
+}
@@ -0,0 +1,156 @@
+package mailer
+
+import (
+	"campaignku/tracing"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Pengaturan percobaan ulang kirim email. Jeda antar percobaan dobel tiap kali gagal, mulai dari sendBackoff.
+const (
+	maxSendAttempts = 5
+	sendBackoff     = 2 * time.Second
+)
+
+// Error yang bisa dibalikin Send. Dua-duanya artinya emailnya nggak bakal dikirim.
+var (
+	ErrQueueFull    = errors.New("antrean email penuh")
+	ErrMailerClosed = errors.New("layanan email sudah ditutup")
+)
+
+// Service adalah interface untuk layanan email.
+type Service interface {
+	Send(ctx context.Context, to Recipient, template string, data interface{}) error
+}
+
+// service adalah implementasi Service. Email disusun langsung, tapi dikirimnya di belakang sama beberapa worker.
+// mu dan closed ngejaga antrean biar Send yang telat (misal dari request yang belum kelar pas aplikasi dimatiin)
+// nggak ngirim ke antrean yang udah ditutup.
+type service struct {
+	driver   Driver
+	renderer *renderer
+	queue    chan queuedMessage
+	done     chan struct{} // Ditutup sama Close, buat motong jeda percobaan ulang.
+	mu       sync.RWMutex
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// queuedMessage adalah email di antrean plus span yang ngirimnya, biar pengiriman di belakang bisa disambungin ke trace asalnya.
+type queuedMessage struct {
+	message Message
+	origin  trace.SpanContext
+}
+
+// NewService bikin service email dan langsung nyalain worker-nya.
+// appURL dipake buat bikin tautan absolut di email, size itu kapasitas antreannya.
+func NewService(driver Driver, appURL string, workers int, size int) *service {
+	s := &service{
+		driver:   driver,
+		renderer: newRenderer(appURL),
+		queue:    make(chan queuedMessage, size),
+		done:     make(chan struct{}),
+	}
+
+	for i := 0; i < workers; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+
+	return s
+}
+
+// Send nyusun email dari template lalu masukin ke antrean, jadi yang manggil nggak perlu nunggu emailnya terkirim.
+// Error cuma dibalikin kalo template-nya gagal disusun, antreannya penuh, atau service-nya udah ditutup.
+func (s *service) Send(ctx context.Context, to Recipient, template string, data interface{}) error {
+	message, err := s.renderer.render(to, template, data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return ErrMailerClosed
+	}
+
+	select {
+	case s.queue <- queuedMessage{message, trace.SpanContextFromContext(ctx)}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close nutup antrean dan nunggu semua email yang udah masuk antrean selesai dikirim.
+// Email yang gagal nggak dicoba ulang lagi, biar aplikasi nggak ketahan lama pas dimatiin. Aman dipanggil lebih dari sekali.
+func (s *service) Close() {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// work ngirim email dari antrean satu per satu sampai antreannya ditutup.
+func (s *service) work() {
+	defer s.wg.Done()
+
+	for queued := range s.queue {
+		s.deliver(queued)
+	}
+}
+
+// deliver ngirim satu email, dicoba ulang kalo gagal. Kalo tetep gagal sampai batasnya atau service-nya keburu ditutup,
+// emailnya dibuang dan dicatat.
+// Pengirimannya jadi span sendiri yang ditautin ke span asal email-nya, soalnya request asalnya udah lama selesai.
+func (s *service) deliver(queued queuedMessage) {
+	message := queued.message
+
+	var err error
+	ctx, end := tracing.Start(context.Background(), "mailer.deliver", trace.WithLinks(trace.Link{SpanContext: queued.origin}))
+	defer func() { end(err) }()
+
+	delay := sendBackoff
+	for attempt := 1; ; attempt++ {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int("mail.attempts", attempt))
+
+		err = s.driver.Send(message)
+		if err == nil {
+			return
+		}
+		if attempt >= maxSendAttempts {
+			slog.ErrorContext(ctx, "gagal mengirim email", "subject", message.Subject, "to", message.To.Email, "attempts", attempt, "error", err)
+			return
+		}
+
+		if !s.wait(delay) {
+			slog.ErrorContext(ctx, "gagal mengirim email sebelum layanan email ditutup", "subject", message.Subject, "to", message.To.Email, "attempts", attempt, "error", err)
+			return
+		}
+		delay *= 2
+	}
+}
+
+// wait nunggu selama delay sebelum percobaan berikutnya. Balikin false kalo service-nya ditutup duluan.
+func (s *service) wait(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-s.done:
+		return false
+	}
+}
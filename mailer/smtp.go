@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// smtpDriver ngirim email lewat server SMTP.
+type smtpDriver struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPDriver bikin driver SMTP. Kalo username kosong, email dikirim tanpa login ke server-nya.
+func NewSMTPDriver(host string, port string, username string, password string, from string) *smtpDriver {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpDriver{net.JoinHostPort(host, port), auth, from}
+}
+
+// Send ngirim satu email ke server SMTP.
+func (d *smtpDriver) Send(message Message) error {
+	sender, err := mail.ParseAddress(d.from)
+	if err != nil {
+		return err
+	}
+
+	body, err := buildMIME(d.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(d.addr, d.auth, sender.Address, []string{message.To.Email}, body)
+}
@@ -0,0 +1,104 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// templateNames adalah semua template yang harus ada di tiap bahasa.
+var templateNames = []string{TemplateWelcome, TemplateBackingReceipt, TemplateCampaignFunded, TemplatePasswordReset}
+
+// emailTemplate adalah satu template email dalam satu bahasa.
+// Versi teks biasanya juga nyimpen judul email di blok "subject".
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// renderer nyusun email dari template yang ditanam di binary.
+type renderer struct {
+	templates map[string]emailTemplate
+}
+
+// newRenderer nge-parse semua template. Tautan di template dibikin absolut pake appURL.
+// Template-nya ikut ke-compile bareng aplikasi, jadi kalo ada yang rusak langsung panic waktu nyala.
+func newRenderer(appURL string) *renderer {
+	funcs := map[string]interface{}{
+		"link":   func(path string) string { return strings.TrimRight(appURL, "/") + path },
+		"rupiah": formatRupiah,
+	}
+
+	templates := map[string]emailTemplate{}
+	for _, locale := range Locales {
+		for _, name := range templateNames {
+			dir := "templates/" + locale + "/"
+			text := texttemplate.Must(texttemplate.New(name+".txt").Funcs(funcs).ParseFS(templateFiles, dir+name+".txt"))
+			html := htmltemplate.Must(htmltemplate.New("layout.html").Funcs(funcs).ParseFS(templateFiles, "templates/layout.html", dir+name+".html"))
+			templates[templateKey(locale, name)] = emailTemplate{text, html}
+		}
+	}
+
+	return &renderer{templates}
+}
+
+// render nyusun email buat penerima dari template dan datanya.
+// Kalo bahasa penerima nggak didukung, dipake DefaultLocale.
+func (r *renderer) render(to Recipient, name string, data interface{}) (Message, error) {
+	message := Message{To: to}
+
+	tmpl, ok := r.templates[templateKey(to.Locale, name)]
+	if !ok {
+		tmpl, ok = r.templates[templateKey(DefaultLocale, name)]
+	}
+	if !ok {
+		return message, fmt.Errorf("template email %q tidak ada", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return message, err
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return message, err
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return message, err
+	}
+
+	message.Subject = strings.TrimSpace(subject.String())
+	message.Text = strings.TrimSpace(text.String()) + "\n"
+	message.HTML = html.String()
+	return message, nil
+}
+
+// templateKey adalah kunci map template buat satu bahasa dan satu nama template.
+func templateKey(locale string, name string) string {
+	return locale + "/" + name
+}
+
+// formatRupiah nampilin nominal pake pemisah ribuan, misal 150000 jadi "Rp150.000".
+func formatRupiah(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		digits = digits[1:]
+	}
+
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + "Rp" + grouped.String()
+}
@@ -5,105 +5,442 @@ import (
 	// Impor package-package yang dibutuhkan.
 	"campaignku/auth"
 	"campaignku/campaign"
+	"campaignku/comment"
+	"campaignku/config"
+	"campaignku/follow"
 	"campaignku/handler"
+	"campaignku/health"
 	"campaignku/helper"
+	"campaignku/logging"
+	"campaignku/mailer"
+	"campaignku/metrics"
+	"campaignku/notification"
+	"campaignku/payment"
+	"campaignku/profile"
+	"campaignku/pubsub"
+	"campaignku/search"
+	"campaignku/tracing"
+	"campaignku/transaction"
 	"campaignku/user"
+	"campaignku/webhook"
+	"context"
+	"crypto/subtle"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
-	"github.com/dgrijalva/jwt-go" // Untuk urusin JWT.
-	"github.com/gin-gonic/gin"    // Gin, framework buat bikin web server.
-	"github.com/joho/godotenv"    // Untuk baca file .env.
-	"gorm.io/driver/mysql"        // Driver MySQL untuk GORM.
-	"gorm.io/gorm"                // GORM, ORM untuk Go.
+	"github.com/dgrijalva/jwt-go"                                                  // Untuk urusin JWT.
+	"github.com/gin-gonic/gin"                                                     // Gin, framework buat bikin web server.
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin" // Tracing request Gin.
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"                // Tracing panggilan HTTP keluar.
+	"gorm.io/driver/mysql"                                                         // Driver MySQL untuk GORM.
+	"gorm.io/driver/postgres"                                                      // Driver PostgreSQL untuk GORM.
+	"gorm.io/driver/sqlite"                                                        // Driver SQLite untuk GORM, butuh cgo.
+	"gorm.io/gorm"                                                                 // GORM, ORM untuk Go.
 )
 
 func main() {
-	// Baca konfigurasi database dari file .env.
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Error loading .env file")
-	}
-
-	// Sambung ke database MySQL pake GORM.
-	dbUser := os.Getenv("DB_USER")         // Username DB.
-	dbPassword := os.Getenv("DB_PASSWORD") // Password DB.
-	dbHost := os.Getenv("DB_HOST")         // Host DB.
-	dbName := os.Getenv("DB_NAME")         // Nama DB.
-	dsn := dbUser + ":" + dbPassword + "@tcp(" + dbHost + ")/" + dbName + "?charset=utf8mb4&parseTime=True&loc=Local"
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	// Baca konfigurasi dari environment, file .env, dan file YAML (kalo ada).
+	// Perintah command line cuma butuh database, jadi pengaturan web server nggak wajib diisi buat itu.
+	load := config.Load
+	if len(os.Args) > 1 {
+		load = config.LoadDatabase
+	}
+	cfg, err := load()
+	if err != nil {
+		log.Fatalf("Konfigurasi tidak valid:\n%v", err)
+	}
+
+	// Semua log (termasuk dari package log bawaan) ditulis terstruktur lewat slog.
+	logger := logging.New(cfg.Log, os.Stderr)
+	slog.SetDefault(logger)
+
+	// Siapin tracing OpenTelemetry sesuai konfigurasi. Span yang belum kekirim dikirim dulu pas aplikasi dimatiin.
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, health.Build().Commit)
 	if err != nil {
-		log.Fatal(err.Error())
+		fatal("gagal nyiapin tracing", err)
+	}
+
+	// Context ini dibatalin begitu proses dapet SIGINT/SIGTERM, jadi semua yang jalan di belakang tau kapan harus berhenti.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Sambung ke database pake GORM, driver-nya sesuai konfigurasi.
+	db, err := openDatabase(cfg.Database, logger)
+	if err != nil {
+		fatal("gagal nyambung ke database", err)
+	}
+
+	// Migrasi cuma butuh koneksi database, jadi langsung dijalanin sebelum service lain disiapin.
+	// Skemanya bisa aja belum ada, jadi jangan sampe ada yang nyentuh tabel duluan.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := runMigrate(db, os.Args[2:])
+		closeDatabase(db)
+		flushTraces(shutdownTracing, cfg.Server.ShutdownTimeout)
+		if err != nil {
+			fatal("perintah gagal", err)
+		}
+		return
+	}
+
+	// Siapin metrik Prometheus. Plugin GORM-nya nyatet lama tiap query dan statistik pool koneksi database.
+	appMetrics := metrics.New()
+	if err := db.Use(appMetrics.GormPlugin()); err != nil {
+		fatal("gagal masang metrik database", err)
+	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		fatal("gagal masang tracing database", err)
 	}
 
 	// Buat repository untuk user dan campaign.
 	userRepository := user.NewRepository(db)
 	campaignRepository := campaign.NewRepository(db)
+	transactionRepository := transaction.NewRepository(db)
+	notificationRepository := notification.NewRepository(db)
+	commentRepository := comment.NewRepository(db)
+	followRepository := follow.NewRepository(db)
+	webhookRepository := webhook.NewRepository(db)
+
+	// Buat index pencarian campaign. Index bawaan hidup di memori, jadi diisi ulang tiap aplikasi nyala.
+	searchIndex := search.NewMemoryIndex(campaign.SearchWeights)
+
+	// Buat broker pubsub buat event real-time. Broker bawaan cuma nyampe ke pelanggan di proses yang sama.
+	broker := pubsub.NewMemoryBroker(16)
+
+	// Buat service email. Email dikirim di belakang sama beberapa worker biar request nggak perlu nunggu.
+	mailService := mailer.NewService(newMailDriver(cfg.Mail), cfg.Server.AppURL, cfg.Mail.Workers, cfg.Mail.QueueSize)
 
 	// Buat service untuk user, campaign, dan autentikasi.
-	userService := user.NewService(userRepository)
-	campaignService := campaign.NewService(campaignRepository)
-	authService := auth.NewService()
+	userService := user.NewTracedService(user.NewService(userRepository, mailService, appMetrics))
+	notificationService := notification.NewService(notificationRepository)
+	campaignService := campaign.NewTracedService(campaign.NewService(campaignRepository, searchIndex, notificationService, broker))
+	authService := auth.NewService(cfg.JWT.SecretKey, cfg.JWT.TokenTTL)
+	paymentService := payment.NewService(cfg.Payment.MidtransServerKey, cfg.Payment.MidtransProduction)
+	commentService := comment.NewService(commentRepository, campaignRepository, notificationService)
+	followService := follow.NewService(followRepository, campaignRepository, userRepository)
+	profileService := profile.NewService(userRepository, campaignRepository, transactionRepository)
+	webhookClient := webhook.NewClient(10 * time.Second)
+	webhookClient.Transport = otelhttp.NewTransport(webhookClient.Transport)
+	webhookService := webhook.NewService(webhookRepository, webhookClient)
+	healthService := health.NewService(
+		health.DatabaseCheck(db),
+		health.MigrationCheck(db),
+		health.StorageCheck(cfg.Storage.ImageDir),
+		health.Check{Name: "payment", Run: paymentService.Ping},
+	)
+	transactionService := transaction.NewService(transactionRepository, campaignRepository, userRepository, paymentService, notificationService, mailService, broker, appMetrics)
+
+	// Event yang bisa dilanggan lewat webhook diteruskan ke service webhook buat dijadwalin pengirimannya.
+	for _, event := range webhook.SupportedEvents {
+		broker.Handle(event, webhookService.HandleEvent)
+	}
+
+	// Kalo dijalanin pake perintah (misal "campaignku migrate up"), jalanin perintahnya aja tanpa nyalain web server.
+	// Email yang sempet masuk antrean tetep dikirim dulu sebelum keluar.
+	if len(os.Args) > 1 {
+		cli := commands{userService, campaignService, transactionService, cfg.Storage.ImageDir}
+		err := cli.run(ctx, os.Args[1:])
+		mailService.Close()
+		closeDatabase(db)
+		flushTraces(shutdownTracing, cfg.Server.ShutdownTimeout)
+		if err != nil {
+			fatal("perintah gagal", err)
+		}
+		return
+	}
+
+	if err := campaignService.RebuildSearchIndex(ctx); err != nil {
+		fatal("gagal ngisi index pencarian", err)
+	}
+
+	// Job latar belakang dicatat di sini biar pas server dimatiin bisa ditunggu sampe putaran terakhirnya selesai.
+	var workers sync.WaitGroup
+	startJob := func(name string, interval time.Duration, job func(ctx context.Context) error) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			runPeriodically(ctx, name, interval, job)
+		}()
+	}
+
+	// Tutup campaign yang udah lewat tanggal akhirnya secara berkala.
+	startJob("close-expired-campaigns", time.Minute, func(ctx context.Context) error {
+		_, err := campaignService.CloseExpiredCampaigns(ctx, time.Now())
+		return err
+	})
+
+	// Refund dukungan di campaign all-or-nothing yang gagal, termasuk nyoba ulang refund yang sempet gagal.
+	startJob("refund-failed-campaigns", time.Minute, func(ctx context.Context) error {
+		return transactionService.RefundFailedCampaigns(ctx, time.Now())
+	})
+
+	// Kirim webhook yang udah waktunya, termasuk nyoba ulang yang sempet gagal.
+	startJob("deliver-webhooks", 15*time.Second, func(ctx context.Context) error {
+		return webhookService.DeliverPending(ctx, time.Now())
+	})
 
 	// Siapin handler buat handle request ke user dan campaign.
-	userHandler := handler.NewUserHandler(userService, authService)
-	campaignHandler := handler.NewCampaignHandler(campaignService)
+	userHandler := handler.NewUserHandler(userService, authService, cfg.Storage.ImageDir)
+	campaignHandler := handler.NewCampaignHandler(campaignService, cfg.Storage.ImageDir)
+	categoryHandler := handler.NewCategoryHandler(campaignService)
+	transactionHandler := handler.NewTransactionHandler(transactionService, broker)
+	commentHandler := handler.NewCommentHandler(commentService)
+	followHandler := handler.NewFollowHandler(followService)
+	profileHandler := handler.NewProfileHandler(profileService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	healthHandler := handler.NewHealthHandler(healthService)
+
+	// Inisialisasi router pake Gin. Log akses dan penanganan panic-nya pake versi sendiri biar ditulis lewat slog
+	// dan bawa request ID, jadi log dari handler, service, dan query database satu request bisa dirangkai.
+	router := gin.New()
+	router.Use(logging.RequestIDMiddleware())
+	router.Use(otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(isTraced)))
+	router.Use(logging.AccessLogMiddleware(logger))
+	router.Use(logging.RecoveryMiddleware(logger))
+	router.Use(appMetrics.Middleware())
+	router.Use(timeoutMiddleware(cfg.Server))
+
+	// Endpoint buat orchestrator, sengaja di luar /api/v1 biar nggak ikut berubah kalo versi API-nya naik.
+	router.GET("/healthz", healthHandler.Live)
+	router.GET("/readyz", healthHandler.Ready)
+	router.GET("/version", healthHandler.Version)
+	router.GET("/metrics", metricsAuthMiddleware(cfg.Server.MetricsToken), gin.WrapH(appMetrics.Handler()))
 
-	// Inisialisasi router pake Gin.
-	router := gin.Default()
 	api := router.Group("/api/v1")
 
 	// Set endpoint dan method yang sesuai.
 	api.POST("/users", userHandler.RegisterUser)
 	api.POST("/sessions", userHandler.Login)
 	api.POST("/email_checkers", userHandler.CheckEmailAvailability)
+	api.POST("/password_resets", userHandler.RequestPasswordReset)
+	api.PUT("/password_resets", userHandler.ResetPassword)
 	api.POST("/avatars", authMiddleware(authService, userService), userHandler.UploadAvatar)
+	api.GET("/users/:id/profile", profileHandler.GetProfile)
+	api.PUT("/profile", authMiddleware(authService, userService), userHandler.UpdateProfile)
+	api.POST("/users/:id/follow", authMiddleware(authService, userService), followHandler.FollowCreator)
+	api.DELETE("/users/:id/follow", authMiddleware(authService, userService), followHandler.UnfollowCreator)
+	api.GET("/feed", authMiddleware(authService, userService), followHandler.GetFeed)
+	api.GET("/addresses", authMiddleware(authService, userService), userHandler.GetAddresses)
+	api.POST("/addresses", authMiddleware(authService, userService), userHandler.CreateAddress)
+	api.PUT("/addresses/:id", authMiddleware(authService, userService), userHandler.UpdateAddress)
+	api.DELETE("/addresses/:id", authMiddleware(authService, userService), userHandler.DeleteAddress)
 	api.GET("/campaigns", campaignHandler.GetCampaigns)
+	api.GET("/campaigns/search", campaignHandler.SearchCampaigns)
+	api.POST("/campaigns", authMiddleware(authService, userService), campaignHandler.CreateCampaign)
+	api.GET("/campaigns/:id", campaignHandler.GetCampaign)
+	api.PUT("/campaigns/:id", authMiddleware(authService, userService), campaignHandler.UpdateCampaign)
+	api.POST("/campaigns/:id/images", authMiddleware(authService, userService), campaignHandler.UploadCampaignImage)
+	api.POST("/campaigns/:id/rewards", authMiddleware(authService, userService), campaignHandler.CreateReward)
+	api.PUT("/campaigns/:id/rewards/:reward_id", authMiddleware(authService, userService), campaignHandler.UpdateReward)
+	api.DELETE("/campaigns/:id/rewards/:reward_id", authMiddleware(authService, userService), campaignHandler.DeleteReward)
+	api.POST("/campaigns/:id/follow", authMiddleware(authService, userService), followHandler.FollowCampaign)
+	api.DELETE("/campaigns/:id/follow", authMiddleware(authService, userService), followHandler.UnfollowCampaign)
+	api.GET("/campaigns/:id/stream", closeOnShutdown(ctx), transactionHandler.StreamCampaignProgress)
+	api.GET("/campaigns/:id/updates", optionalAuthMiddleware(authService, userService), campaignHandler.GetCampaignUpdates)
+	api.POST("/campaigns/:id/updates", authMiddleware(authService, userService), campaignHandler.CreateCampaignUpdate)
+	api.PUT("/campaigns/:id/updates/:update_id", authMiddleware(authService, userService), campaignHandler.UpdateCampaignUpdate)
+	api.DELETE("/campaigns/:id/updates/:update_id", authMiddleware(authService, userService), campaignHandler.DeleteCampaignUpdate)
+	api.GET("/campaigns/:id/comments", optionalAuthMiddleware(authService, userService), commentHandler.GetComments)
+	api.POST("/campaigns/:id/comments", authMiddleware(authService, userService), commentHandler.CreateComment)
+	api.PUT("/campaigns/:id/comments/:comment_id", authMiddleware(authService, userService), commentHandler.UpdateComment)
+	api.DELETE("/campaigns/:id/comments/:comment_id", authMiddleware(authService, userService), commentHandler.DeleteComment)
+	api.PUT("/campaigns/:id/comments/:comment_id/moderation", authMiddleware(authService, userService), commentHandler.ModerateComment)
+	api.GET("/campaigns/:id/comments/:comment_id/history", optionalAuthMiddleware(authService, userService), commentHandler.GetCommentHistory)
+	api.GET("/campaigns/:id/fulfilments", authMiddleware(authService, userService), transactionHandler.GetFulfilments)
+	api.PUT("/campaigns/:id/fulfilments", authMiddleware(authService, userService), transactionHandler.UpdateFulfilments)
+	api.GET("/campaigns/:id/shipping.csv", authMiddleware(authService, userService), transactionHandler.ExportShippingList)
+	api.GET("/transactions", authMiddleware(authService, userService), transactionHandler.GetUserTransactions)
+	api.POST("/transactions", authMiddleware(authService, userService), transactionHandler.CreateTransaction)
+	api.POST("/transactions/notification", transactionHandler.GetNotification)
+	api.GET("/notifications", authMiddleware(authService, userService), notificationHandler.GetNotifications)
+	api.GET("/notifications/unread_count", authMiddleware(authService, userService), notificationHandler.GetUnreadCount)
+	api.PUT("/notifications/read", authMiddleware(authService, userService), notificationHandler.MarkAllAsRead)
+	api.PUT("/notifications/:id/read", authMiddleware(authService, userService), notificationHandler.MarkAsRead)
+	api.GET("/notifications/preferences", authMiddleware(authService, userService), notificationHandler.GetPreferences)
+	api.PUT("/notifications/preferences", authMiddleware(authService, userService), notificationHandler.UpdatePreferences)
+	api.GET("/webhooks", authMiddleware(authService, userService), webhookHandler.GetSubscriptions)
+	api.POST("/webhooks", authMiddleware(authService, userService), webhookHandler.CreateSubscription)
+	api.PUT("/webhooks/:id", authMiddleware(authService, userService), webhookHandler.UpdateSubscription)
+	api.DELETE("/webhooks/:id", authMiddleware(authService, userService), webhookHandler.DeleteSubscription)
+	api.GET("/webhooks/:id/deliveries", authMiddleware(authService, userService), webhookHandler.GetDeliveries)
+	api.POST("/webhooks/:id/test", authMiddleware(authService, userService), webhookHandler.SendTestEvent)
+	api.GET("/categories", categoryHandler.GetCategories)
+	api.POST("/categories", authMiddleware(authService, userService), adminMiddleware(), categoryHandler.CreateCategory)
+	api.PUT("/categories/:id", authMiddleware(authService, userService), adminMiddleware(), categoryHandler.UpdateCategory)
+	api.DELETE("/categories/:id", authMiddleware(authService, userService), adminMiddleware(), categoryHandler.DeleteCategory)
+
+	// Jalankan server di port dari konfigurasi sampe ada sinyal buat berhenti (atau servernya gagal jalan).
+	server := newHTTPServer(cfg.Server, router)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- serve(server, cfg.Server)
+	}()
+	slog.Info("server jalan", "port", cfg.Server.Port, "tls", cfg.Server.TLSEnabled())
+
+	var runErr error
+	select {
+	case runErr = <-serverErr:
+	case <-ctx.Done():
+	}
+
+	// Sinyal kedua langsung matiin proses kayak biasa, buat jaga-jaga kalo beresinnya nyangkut.
+	stop()
+	slog.Info("mematikan server")
 
-	// Jalankan server di port 8080.
-	router.Run()
+	// Server berhenti nerima koneksi baru dan nunggu request yang lagi jalan selesai, abis itu job latar belakang
+	// dan antrean email dibiarin beres dulu sebelum koneksi database ditutup. Semuanya dibatesin ShutdownTimeout.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("gagal nunggu request yang lagi jalan", "error", err)
+	}
+	waitUntil(shutdownCtx, "job latar belakang", workers.Wait)
+	waitUntil(shutdownCtx, "antrean email", mailService.Close)
+	closeDatabase(db)
+	flushTraces(shutdownTracing, cfg.Server.ShutdownTimeout)
+
+	if runErr != nil {
+		fatal("server gagal jalan", runErr)
+	}
+	slog.Info("server berhenti")
 }
 
-// Fungsi middleware buat otentikasi.
-func authMiddleware(authService auth.Service, userService user.Service) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Dapetin header Authorization dari request.
-		authHeader := c.GetHeader("Authorization")
+// Fungsi buat nyatet error yang bikin aplikasi nggak bisa lanjut, terus keluar.
+func fatal(message string, err error) {
+	slog.Error(message, "error", err)
+	os.Exit(1)
+}
 
-		// Cek header ada token Bearer-nya apa enggak.
-		if !strings.Contains(authHeader, "Bearer") {
-			response := helper.ApiResponse("Tidak diizinkan", http.StatusUnauthorized, "error", nil)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+// Fungsi buat buka koneksi database sesuai driver di konfigurasi.
+// Log query-nya ditulis lewat logger aplikasi.
+func openDatabase(cfg config.DatabaseConfig, logger *slog.Logger) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		dialector = postgres.Open(cfg.DSN())
+	case config.DriverSQLite:
+		dialector = sqlite.Open(cfg.DSN())
+	default:
+		dialector = mysql.Open(cfg.DSN())
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logging.NewGormLogger(logger)})
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite cuma bisa ditulis satu koneksi dalam satu waktu, jadi koneksinya dibatesin satu biar nggak rebutan kunci.
+	// Sekalian biar database ":memory:" nggak kebelah jadi beberapa database terpisah.
+	if cfg.Driver == config.DriverSQLite {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	return db, nil
+}
+
+// Fungsi buat milih cara kirim email sesuai konfigurasi. "smtp" ngirim lewat server SMTP,
+// "file" cuma nulis email ke folder buat dicek waktu development.
+func newMailDriver(cfg config.MailConfig) mailer.Driver {
+	if cfg.Driver == "smtp" {
+		return mailer.NewSMTPDriver(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	}
+	return mailer.NewFileDriver(cfg.Dir, cfg.From)
+}
+
+// Fungsi buat jalanin job latar belakang sekali di awal, terus tiap interval sampe ctx dibatalin.
+// Kalo job-nya error cuma dicatat aja, job tetep dijalanin lagi di putaran berikutnya.
+// Putaran yang lagi jalan pas ctx dibatalin tetep dibiarin selesai biar nggak berhenti di tengah jalan.
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Tiap putaran jadi satu trace sendiri, jadi query dan panggilan keluar di dalemnya kekumpul jadi satu.
+		jobCtx, end := tracing.Start(context.WithoutCancel(ctx), "job "+name)
+		err := job(jobCtx)
+		end(err)
+		if err != nil {
+			slog.ErrorContext(jobCtx, "job latar belakang gagal", "job", name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
 		}
+	}
+}
+
+// Fungsi buat ngirim span yang belum kekirim dan nutup exporter tracing, dibatesin timeout biar nggak nahan proses keluar.
+func flushTraces(shutdown func(context.Context) error, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		slog.Warn("gagal ngirim sisa trace", "error", err)
+	}
+}
+
+// Fungsi buat milih request yang di-trace. Probe orchestrator dan scrape metrik dilewatin biar trace-nya nggak kebanjiran.
+func isTraced(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/readyz", "/version", "/metrics":
+		return false
+	}
+	return true
+}
 
-		// Ambil dan validasi token dari header.
-		tokenString := ""
-		arrayToken := strings.Split(authHeader, " ")
-		if len(arrayToken) == 2 {
-			tokenString = arrayToken[1]
+// Fungsi middleware buat ngasih batas waktu ke context request. Batas waktunya ngikutin route yang kepilih,
+// jadi query database dan panggilan ke layanan luar ikut dibatalin kalo request-nya kelamaan atau client-nya putus.
+func timeoutMiddleware(cfg config.ServerConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := cfg.TimeoutFor(c.Request.Method, c.FullPath())
+		if timeout <= 0 {
+			return
 		}
 
-		token, err := authService.ValidateToken(tokenString)
-		if err != nil {
-			response := helper.ApiResponse("Tidak diizinkan", http.StatusUnauthorized, "error", nil)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// Fungsi middleware buat ngunci /metrics pake token Bearer. Kalo tokennya kosong, /metrics dibiarin terbuka
+// (misal kalo port-nya cuma bisa diakses dari jaringan internal).
+func metricsAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
 			return
 		}
 
-		// Cek claim dari token.
-		claim, ok := token.Claims.(jwt.MapClaims)
-		if !ok || !token.Valid {
+		given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
 			response := helper.ApiResponse("Tidak diizinkan", http.StatusUnauthorized, "error", nil)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 			return
 		}
+	}
+}
 
-		// Ambil userID dari claim, cari user di service.
-		userID := int(claim["user_id"].(float64))
-		user, err := userService.GetUserByID(userID)
-		if err != nil {
+// Fungsi middleware buat otentikasi.
+func authMiddleware(authService auth.Service, userService user.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := authenticate(c, authService, userService)
+		if !ok {
 			response := helper.ApiResponse("Tidak diizinkan", http.StatusUnauthorized, "error", nil)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 			return
@@ -113,3 +450,64 @@ func authMiddleware(authService auth.Service, userService user.Service) gin.Hand
 		c.Set("currentUser", user)
 	}
 }
+
+// Fungsi middleware buat endpoint publik yang isinya bisa beda kalo user-nya login.
+// Request tanpa token (atau tokennya nggak valid) tetep diterusin, cuma currentUser-nya nggak di-set.
+func optionalAuthMiddleware(authService auth.Service, userService user.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user, ok := authenticate(c, authService, userService); ok {
+			c.Set("currentUser", user)
+		}
+	}
+}
+
+// Fungsi buat dapetin user dari token Bearer di header Authorization.
+func authenticate(c *gin.Context, authService auth.Service, userService user.Service) (user.User, bool) {
+	// Dapetin header Authorization dari request.
+	authHeader := c.GetHeader("Authorization")
+
+	// Cek header ada token Bearer-nya apa enggak.
+	if !strings.Contains(authHeader, "Bearer") {
+		return user.User{}, false
+	}
+
+	// Ambil dan validasi token dari header.
+	tokenString := ""
+	arrayToken := strings.Split(authHeader, " ")
+	if len(arrayToken) == 2 {
+		tokenString = arrayToken[1]
+	}
+
+	token, err := authService.ValidateToken(tokenString)
+	if err != nil {
+		return user.User{}, false
+	}
+
+	// Cek claim dari token.
+	claim, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return user.User{}, false
+	}
+
+	// Ambil userID dari claim, cari user di service.
+	userID := int(claim["user_id"].(float64))
+	currentUser, err := userService.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		return user.User{}, false
+	}
+
+	return currentUser, true
+}
+
+// Fungsi middleware buat batesin endpoint cuma buat admin. Harus dipasang setelah authMiddleware.
+func adminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentUser := c.MustGet("currentUser").(user.User)
+
+		if currentUser.Role != user.RoleAdmin {
+			response := helper.ApiResponse("Akses ditolak", http.StatusForbidden, "error", nil)
+			c.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// startKey adalah kunci buat nyimpen waktu mulai query di statement GORM.
+const startKey = "metrics:start"
+
+// gormPlugin adalah plugin GORM yang nyatet lama tiap query dan statistik pool koneksinya.
+type gormPlugin struct {
+	metrics *Metrics
+}
+
+// GormPlugin balikin plugin GORM buat dipasang pake db.Use.
+func (m *Metrics) GormPlugin() gorm.Plugin {
+	return &gormPlugin{m}
+}
+
+// Name adalah nama plugin di GORM.
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize masang callback sebelum dan sesudah tiap jenis operasi GORM,
+// sekalian ndaftarin statistik pool koneksi database (koneksi kepake, nganggur, nunggu, dll).
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := p.metrics.registry.Register(collectors.NewDBStatsCollector(sqlDB, namespace)); err != nil {
+		return err
+	}
+
+	callback := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", callback.Create().Before("gorm:create").Register, callback.Create().After("gorm:create").Register},
+		{"query", callback.Query().Before("gorm:query").Register, callback.Query().After("gorm:query").Register},
+		{"update", callback.Update().Before("gorm:update").Register, callback.Update().After("gorm:update").Register},
+		{"delete", callback.Delete().Before("gorm:delete").Register, callback.Delete().After("gorm:delete").Register},
+		{"row", callback.Row().Before("gorm:row").Register, callback.Row().After("gorm:row").Register},
+		{"raw", callback.Raw().Before("gorm:raw").Register, callback.Raw().After("gorm:raw").Register},
+	}
+
+	for _, processor := range processors {
+		if err := processor.before("metrics:before_"+processor.operation, p.before); err != nil {
+			return err
+		}
+		if err := processor.after("metrics:after_"+processor.operation, p.after(processor.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// before nyimpen waktu mulai query.
+func (p *gormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+// after nyatet lama query dan error-nya. Data yang nggak ketemu bukan dianggap error.
+func (p *gormPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+
+		p.metrics.dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			p.metrics.dbQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
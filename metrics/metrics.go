@@ -0,0 +1,136 @@
+// Package metrics nyediain metrik Prometheus buat aplikasi: request HTTP, query database, dan event bisnis.
+// Semua metrik didaftarin ke registry sendiri (bukan registry global Prometheus) dan dikeluarin lewat Handler.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace adalah awalan nama semua metrik aplikasi.
+const namespace = "campaignku"
+
+// Recorder adalah interface buat nyatet event bisnis. Dipake service biar nggak tergantung langsung ke Prometheus.
+type Recorder interface {
+	UserRegistered()                                    // Fungsi buat nyatet pendaftaran user baru.
+	LoginAttempted(succeeded bool)                      // Fungsi buat nyatet percobaan login, berhasil atau gagal.
+	TransactionStatusChanged(status string, amount int) // Fungsi buat nyatet transaksi yang masuk ke status tertentu, lengkap sama nominalnya.
+}
+
+// Metrics nyimpen registry dan semua metrik aplikasi.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	httpInFlight        prometheus.Gauge
+
+	dbQueryDuration *prometheus.HistogramVec
+	dbQueryErrors   *prometheus.CounterVec
+
+	registrations      prometheus.Counter
+	logins             *prometheus.CounterVec
+	transactions       *prometheus.CounterVec
+	transactionAmounts *prometheus.CounterVec
+}
+
+// New bikin semua metrik aplikasi plus metrik bawaan runtime Go dan proses.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Jumlah request HTTP per route, method, dan status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Lama request HTTP per route, method, dan status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Jumlah request HTTP yang lagi diproses.",
+		}),
+
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Lama query database per operasi dan tabel.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		dbQueryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_query_errors_total",
+			Help:      "Jumlah query database yang gagal per operasi dan tabel, nggak termasuk data yang nggak ketemu.",
+		}, []string{"operation", "table"}),
+
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_registrations_total",
+			Help:      "Jumlah user yang daftar.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_logins_total",
+			Help:      "Jumlah percobaan login per hasilnya (success atau failure).",
+		}, []string{"result"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_total",
+			Help:      "Jumlah transaksi yang masuk ke tiap status.",
+		}, []string{"status"}),
+		transactionAmounts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transaction_amount_rupiah_total",
+			Help:      "Total nominal transaksi yang masuk ke tiap status. Dana terkumpul ada di status paid, yang dibalikin di status refunded.",
+		}, []string{"status"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.httpInFlight,
+		m.dbQueryDuration,
+		m.dbQueryErrors,
+		m.registrations,
+		m.logins,
+		m.transactions,
+		m.transactionAmounts,
+	)
+
+	return m
+}
+
+// Handler balikin handler HTTP yang ngeluarin semua metrik dalam format Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// UserRegistered nyatet satu pendaftaran user baru.
+func (m *Metrics) UserRegistered() {
+	m.registrations.Inc()
+}
+
+// LoginAttempted nyatet satu percobaan login.
+func (m *Metrics) LoginAttempted(succeeded bool) {
+	result := "failure"
+	if succeeded {
+		result = "success"
+	}
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// TransactionStatusChanged nyatet satu transaksi yang masuk ke status tertentu.
+func (m *Metrics) TransactionStatusChanged(status string, amount int) {
+	m.transactions.WithLabelValues(status).Inc()
+	m.transactionAmounts.WithLabelValues(status).Add(float64(amount))
+}
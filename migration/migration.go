@@ -0,0 +1,138 @@
+// Package migration menyediakan migrasi skema database yang ditanam di binary, lengkap sama versinya.
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*/*.sql
+var files embed.FS
+
+// Dialek database yang didukung. Namanya sama kaya nama dialector GORM dan nama folder migrasinya.
+const (
+	DialectMySQL    = "mysql"
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+)
+
+// Migration adalah satu perubahan skema. Up buat nerapin, Down buat ngebatalin.
+// File-nya dinamain <versi>_<nama>.up.sql dan <versi>_<nama>.down.sql, misal 0001_create_users.up.sql,
+// dan ditaruh di folder sql/<dialek>. Tiap dialek harus punya daftar migrasi yang sama persis.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status adalah keadaan satu migrasi di database. AppliedAt nil artinya belum diterapin.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// SchemaMigration adalah catatan migrasi yang udah diterapin, disimpen di tabel schema_migrations.
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// Load baca semua migrasi yang ditanam buat dialek tertentu, diurutin dari versi paling lama.
+// Tiap migrasi wajib punya file up dan down.
+func Load(dialect string) ([]Migration, error) {
+	dir := path.Join("sql", dialect)
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("migrasi buat database %q tidak tersedia", dialect)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("file migrasi %s harus berakhiran .up.sql atau .down.sql", name)
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionText, migrationName, found := strings.Cut(base, "_")
+		version, err := strconv.Atoi(versionText)
+		if !found || err != nil || version <= 0 {
+			return nil, fmt.Errorf("file migrasi %s harus diawali nomor versi, misal 0001_create_users", name)
+		}
+
+		content, err := files.ReadFile(path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: migrationName}
+			byVersion[version] = migration
+		}
+		if migration.Name != migrationName {
+			return nil, fmt.Errorf("versi migrasi %d dipakai dua nama: %s dan %s", version, migration.Name, migrationName)
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migrasi %04d_%s harus punya file up dan down", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// statements mecah isi file SQL jadi perintah-perintah terpisah, soalnya nggak semua driver bisa jalanin banyak perintah sekaligus.
+// Perintah dipisah titik koma di akhir baris, baris komentar "--" dilewatin.
+func statements(script string) []string {
+	var result []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			result = append(result, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		result = append(result, rest)
+	}
+	return result
+}
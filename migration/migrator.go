@@ -0,0 +1,198 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNothingToRollback dibalikin kalo belum ada migrasi yang bisa dibatalin.
+var ErrNothingToRollback = errors.New("belum ada migrasi yang diterapkan")
+
+// Migrator adalah interface untuk nerapin dan ngebatalin migrasi.
+type Migrator interface {
+	Up() ([]Migration, error)            // Nerapin semua migrasi yang belum diterapin, balikin yang baru diterapin.
+	Down(steps int) ([]Migration, error) // Ngebatalin beberapa migrasi terakhir, balikin yang dibatalin.
+	Status() ([]Status, error)           // Dapetin keadaan semua migrasi.
+	Pending() ([]Migration, error)       // Dapetin migrasi yang belum diterapin tanpa ngubah apa-apa di database.
+}
+
+// Perintah buat bikin tabel schema_migrations di tiap dialek.
+var schemaMigrationsTable = map[string]string{
+	DialectMySQL: `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    applied_at DATETIME NOT NULL,
+    PRIMARY KEY (version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`,
+	DialectPostgres: `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (version)
+)`,
+	DialectSQLite: `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at DATETIME NOT NULL
+)`,
+}
+
+// migrator adalah implementasi Migrator pake GORM.
+type migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+}
+
+// NewMigrator bikin migrator buat database yang diberikan, pake migrasi yang ditanam di binary sesuai dialek database-nya.
+func NewMigrator(db *gorm.DB) (*migrator, error) {
+	dialect := db.Dialector.Name()
+
+	migrations, err := Load(dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &migrator{db, dialect, migrations}, nil
+}
+
+// Up nerapin semua migrasi yang belum diterapin, urut dari versi paling lama.
+// Kalo satu migrasi gagal, migrasi setelahnya nggak dijalanin.
+func (m *migrator) Up() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := m.run(migration.Up, func(tx *gorm.DB) error {
+			record := SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+			return tx.Create(&record).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migrasi %04d_%s gagal: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down ngebatalin migrasi yang terakhir diterapin sebanyak steps, mulai dari versi paling baru.
+func (m *migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) == 0 {
+		return nil, ErrNothingToRollback
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := m.run(migration.Down, func(tx *gorm.DB) error {
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("rollback migrasi %04d_%s gagal: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Status dapetin keadaan semua migrasi yang ditanam, urut dari versi paling lama.
+func (m *migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending dapetin migrasi yang ditanam tapi belum diterapin, urut dari versi paling lama.
+// Beda sama Status, tabel schema_migrations nggak dibikin kalo belum ada, jadi aman dipanggil pake user database yang cuma boleh baca.
+func (m *migrator) Pending() ([]Migration, error) {
+	if !m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return m.migrations, nil
+	}
+
+	var versions []int
+	if err := m.db.Model(&SchemaMigration{}).Pluck("version", &versions).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// applied ngambil catatan migrasi yang udah diterapin. Tabel schema_migrations dibikin dulu kalo belum ada.
+func (m *migrator) applied() (map[int]SchemaMigration, error) {
+	err := m.db.Exec(schemaMigrationsTable[m.dialect]).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var records []SchemaMigration
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// run jalanin isi satu file migrasi perintah demi perintah, terus nyatet hasilnya pake record.
+// PostgreSQL dan SQLite bisa nge-rollback DDL, jadi di sana semuanya dibungkus satu transaksi.
+// MySQL langsung nge-commit perintah DDL, jadi migrasi yang gagal di tengah jalan perlu dibenerin manual.
+func (m *migrator) run(script string, record func(tx *gorm.DB) error) error {
+	apply := func(tx *gorm.DB) error {
+		for _, statement := range statements(script) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return record(tx)
+	}
+
+	if m.dialect == DialectMySQL {
+		return apply(m.db)
+	}
+	return m.db.Transaction(apply)
+}
@@ -0,0 +1,7 @@
+DROP TABLE campaign_updates;
+DROP TABLE rewards;
+DROP TABLE campaign_tags;
+DROP TABLE tags;
+DROP TABLE campaign_images;
+DROP TABLE campaigns;
+DROP TABLE categories;
@@ -0,0 +1,98 @@
+CREATE TABLE categories (
+    id INT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(50) NOT NULL,
+    slug VARCHAR(60) NOT NULL,
+    icon VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY categories_slug_unique (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE campaigns (
+    id INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    category_id INT NULL,
+    name VARCHAR(255) NOT NULL,
+    short_description VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    backer_count INT NOT NULL DEFAULT 0,
+    follower_count INT NOT NULL DEFAULT 0,
+    goal_amount INT NOT NULL DEFAULT 0,
+    current_amount INT NOT NULL DEFAULT 0,
+    slug VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    funding_mode VARCHAR(20) NOT NULL DEFAULT 'flexible',
+    start_date DATETIME NOT NULL,
+    end_date DATETIME NOT NULL,
+    closed_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY campaigns_slug_unique (slug),
+    KEY campaigns_user_id_index (user_id),
+    KEY campaigns_category_id_index (category_id),
+    KEY campaigns_status_end_date_index (status, end_date),
+    CONSTRAINT campaigns_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT campaigns_category_id_foreign FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE campaign_images (
+    id INT NOT NULL AUTO_INCREMENT,
+    campaign_id INT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    is_primary TINYINT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY campaign_images_campaign_id_index (campaign_id),
+    CONSTRAINT campaign_images_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE tags (
+    id INT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(30) NOT NULL,
+    slug VARCHAR(40) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY tags_slug_unique (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE campaign_tags (
+    campaign_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    PRIMARY KEY (campaign_id, tag_id),
+    KEY campaign_tags_tag_id_index (tag_id),
+    CONSTRAINT campaign_tags_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE,
+    CONSTRAINT campaign_tags_tag_id_foreign FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE rewards (
+    id INT NOT NULL AUTO_INCREMENT,
+    campaign_id INT NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    minimum_amount INT NOT NULL,
+    quantity INT NOT NULL DEFAULT 0,
+    reserved_count INT NOT NULL DEFAULT 0,
+    estimated_delivery DATETIME NULL,
+    requires_shipping TINYINT(1) NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY rewards_campaign_id_index (campaign_id),
+    CONSTRAINT rewards_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE campaign_updates (
+    id INT NOT NULL AUTO_INCREMENT,
+    campaign_id INT NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY campaign_updates_campaign_id_created_at_index (campaign_id, created_at),
+    CONSTRAINT campaign_updates_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,36 @@
+CREATE TABLE transactions (
+    id INT NOT NULL AUTO_INCREMENT,
+    campaign_id INT NOT NULL,
+    user_id INT NOT NULL,
+    reward_id INT NULL,
+    amount INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    code VARCHAR(100) NOT NULL,
+    payment_url VARCHAR(255) NOT NULL DEFAULT '',
+    refund_status VARCHAR(20) NOT NULL DEFAULT '',
+    refund_attempts INT NOT NULL DEFAULT 0,
+    refund_error TEXT NOT NULL,
+    next_refund_at DATETIME NULL,
+    refunded_at DATETIME NULL,
+    shipping_recipient_name VARCHAR(100) NOT NULL DEFAULT '',
+    shipping_phone VARCHAR(20) NOT NULL DEFAULT '',
+    shipping_street TEXT NOT NULL,
+    shipping_city VARCHAR(100) NOT NULL DEFAULT '',
+    shipping_province VARCHAR(100) NOT NULL DEFAULT '',
+    shipping_postal_code VARCHAR(10) NOT NULL DEFAULT '',
+    shipping_country VARCHAR(100) NOT NULL DEFAULT '',
+    fulfilment_status VARCHAR(20) NOT NULL DEFAULT '',
+    tracking_number VARCHAR(100) NOT NULL DEFAULT '',
+    shipped_at DATETIME NULL,
+    delivered_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY transactions_code_unique (code),
+    KEY transactions_campaign_id_status_index (campaign_id, status),
+    KEY transactions_user_id_index (user_id),
+    KEY transactions_refund_status_next_refund_at_index (refund_status, next_refund_at),
+    CONSTRAINT transactions_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id),
+    CONSTRAINT transactions_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT transactions_reward_id_foreign FOREIGN KEY (reward_id) REFERENCES rewards (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
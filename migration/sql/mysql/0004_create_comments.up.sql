@@ -0,0 +1,30 @@
+CREATE TABLE comments (
+    id INT NOT NULL AUTO_INCREMENT,
+    campaign_id INT NOT NULL,
+    user_id INT NOT NULL,
+    parent_id INT NULL,
+    body TEXT NOT NULL,
+    is_hidden TINYINT(1) NOT NULL DEFAULT 0,
+    is_pinned TINYINT(1) NOT NULL DEFAULT 0,
+    edited_at DATETIME NULL,
+    deleted_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY comments_campaign_id_parent_id_index (campaign_id, parent_id),
+    KEY comments_parent_id_index (parent_id),
+    KEY comments_user_id_index (user_id),
+    CONSTRAINT comments_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE,
+    CONSTRAINT comments_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT comments_parent_id_foreign FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE comment_revisions (
+    id INT NOT NULL AUTO_INCREMENT,
+    comment_id INT NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY comment_revisions_comment_id_index (comment_id),
+    CONSTRAINT comment_revisions_comment_id_foreign FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,23 @@
+CREATE TABLE campaign_follows (
+    id INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    campaign_id INT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY campaign_follows_user_id_campaign_id_unique (user_id, campaign_id),
+    KEY campaign_follows_campaign_id_index (campaign_id),
+    CONSTRAINT campaign_follows_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT campaign_follows_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE creator_follows (
+    id INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    creator_id INT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY creator_follows_user_id_creator_id_unique (user_id, creator_id),
+    KEY creator_follows_creator_id_index (creator_id),
+    CONSTRAINT creator_follows_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT creator_follows_creator_id_foreign FOREIGN KEY (creator_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
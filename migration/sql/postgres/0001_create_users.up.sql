@@ -0,0 +1,47 @@
+CREATE TABLE users (
+    id SERIAL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    occupation VARCHAR(255) NOT NULL DEFAULT '',
+    bio VARCHAR(500) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL DEFAULT '',
+    avatar_file_name VARCHAR(255) NOT NULL DEFAULT '',
+    role VARCHAR(20) NOT NULL DEFAULT 'user',
+    locale VARCHAR(5) NOT NULL DEFAULT '',
+    follower_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT users_email_unique UNIQUE (email)
+);
+
+CREATE TABLE addresses (
+    id SERIAL,
+    user_id INT NOT NULL,
+    label VARCHAR(50) NOT NULL DEFAULT '',
+    recipient_name VARCHAR(100) NOT NULL DEFAULT '',
+    phone VARCHAR(20) NOT NULL DEFAULT '',
+    street TEXT NOT NULL,
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    province VARCHAR(100) NOT NULL DEFAULT '',
+    postal_code VARCHAR(10) NOT NULL DEFAULT '',
+    country VARCHAR(100) NOT NULL DEFAULT '',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT addresses_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX addresses_user_id_index ON addresses (user_id);
+
+CREATE TABLE password_resets (
+    id SERIAL,
+    user_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT password_resets_token_hash_unique UNIQUE (token_hash),
+    CONSTRAINT password_resets_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
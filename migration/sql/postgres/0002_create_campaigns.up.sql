@@ -0,0 +1,98 @@
+CREATE TABLE categories (
+    id SERIAL,
+    name VARCHAR(50) NOT NULL,
+    slug VARCHAR(60) NOT NULL,
+    icon VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT categories_slug_unique UNIQUE (slug)
+);
+
+CREATE TABLE campaigns (
+    id SERIAL,
+    user_id INT NOT NULL,
+    category_id INT NULL,
+    name VARCHAR(255) NOT NULL,
+    short_description VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    backer_count INT NOT NULL DEFAULT 0,
+    follower_count INT NOT NULL DEFAULT 0,
+    goal_amount INT NOT NULL DEFAULT 0,
+    current_amount INT NOT NULL DEFAULT 0,
+    slug VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    funding_mode VARCHAR(20) NOT NULL DEFAULT 'flexible',
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT campaigns_slug_unique UNIQUE (slug),
+    CONSTRAINT campaigns_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT campaigns_category_id_foreign FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL
+);
+CREATE INDEX campaigns_user_id_index ON campaigns (user_id);
+CREATE INDEX campaigns_category_id_index ON campaigns (category_id);
+CREATE INDEX campaigns_status_end_date_index ON campaigns (status, end_date);
+
+CREATE TABLE campaign_images (
+    id SERIAL,
+    campaign_id INT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    is_primary SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT campaign_images_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_images_campaign_id_index ON campaign_images (campaign_id);
+
+CREATE TABLE tags (
+    id SERIAL,
+    name VARCHAR(30) NOT NULL,
+    slug VARCHAR(40) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT tags_slug_unique UNIQUE (slug)
+);
+
+CREATE TABLE campaign_tags (
+    campaign_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    PRIMARY KEY (campaign_id, tag_id),
+    CONSTRAINT campaign_tags_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE,
+    CONSTRAINT campaign_tags_tag_id_foreign FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_tags_tag_id_index ON campaign_tags (tag_id);
+
+CREATE TABLE rewards (
+    id SERIAL,
+    campaign_id INT NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    minimum_amount INT NOT NULL,
+    quantity INT NOT NULL DEFAULT 0,
+    reserved_count INT NOT NULL DEFAULT 0,
+    estimated_delivery TIMESTAMP NULL,
+    requires_shipping BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT rewards_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX rewards_campaign_id_index ON rewards (campaign_id);
+
+CREATE TABLE campaign_updates (
+    id SERIAL,
+    campaign_id INT NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT campaign_updates_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_updates_campaign_id_created_at_index ON campaign_updates (campaign_id, created_at);
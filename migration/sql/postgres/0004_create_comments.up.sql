@@ -0,0 +1,30 @@
+CREATE TABLE comments (
+    id SERIAL,
+    campaign_id INT NOT NULL,
+    user_id INT NOT NULL,
+    parent_id INT NULL,
+    body TEXT NOT NULL,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    is_pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    edited_at TIMESTAMP NULL,
+    deleted_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT comments_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE,
+    CONSTRAINT comments_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT comments_parent_id_foreign FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+CREATE INDEX comments_campaign_id_parent_id_index ON comments (campaign_id, parent_id);
+CREATE INDEX comments_parent_id_index ON comments (parent_id);
+CREATE INDEX comments_user_id_index ON comments (user_id);
+
+CREATE TABLE comment_revisions (
+    id SERIAL,
+    comment_id INT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT comment_revisions_comment_id_foreign FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+CREATE INDEX comment_revisions_comment_id_index ON comment_revisions (comment_id);
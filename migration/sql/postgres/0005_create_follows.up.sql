@@ -0,0 +1,23 @@
+CREATE TABLE campaign_follows (
+    id SERIAL,
+    user_id INT NOT NULL,
+    campaign_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT campaign_follows_user_id_campaign_id_unique UNIQUE (user_id, campaign_id),
+    CONSTRAINT campaign_follows_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT campaign_follows_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_follows_campaign_id_index ON campaign_follows (campaign_id);
+
+CREATE TABLE creator_follows (
+    id SERIAL,
+    user_id INT NOT NULL,
+    creator_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    CONSTRAINT creator_follows_user_id_creator_id_unique UNIQUE (user_id, creator_id),
+    CONSTRAINT creator_follows_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT creator_follows_creator_id_foreign FOREIGN KEY (creator_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX creator_follows_creator_id_index ON creator_follows (creator_id);
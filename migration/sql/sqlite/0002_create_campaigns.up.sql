@@ -0,0 +1,92 @@
+CREATE TABLE categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL,
+    slug VARCHAR(60) NOT NULL,
+    icon VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT categories_slug_unique UNIQUE (slug)
+);
+
+CREATE TABLE campaigns (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER NULL,
+    name VARCHAR(255) NOT NULL,
+    short_description VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    backer_count INTEGER NOT NULL DEFAULT 0,
+    follower_count INTEGER NOT NULL DEFAULT 0,
+    goal_amount INTEGER NOT NULL DEFAULT 0,
+    current_amount INTEGER NOT NULL DEFAULT 0,
+    slug VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    funding_mode VARCHAR(20) NOT NULL DEFAULT 'flexible',
+    start_date DATETIME NOT NULL,
+    end_date DATETIME NOT NULL,
+    closed_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT campaigns_slug_unique UNIQUE (slug),
+    CONSTRAINT campaigns_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT campaigns_category_id_foreign FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL
+);
+CREATE INDEX campaigns_user_id_index ON campaigns (user_id);
+CREATE INDEX campaigns_category_id_index ON campaigns (category_id);
+CREATE INDEX campaigns_status_end_date_index ON campaigns (status, end_date);
+
+CREATE TABLE campaign_images (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    campaign_id INTEGER NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    is_primary INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT campaign_images_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_images_campaign_id_index ON campaign_images (campaign_id);
+
+CREATE TABLE tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(30) NOT NULL,
+    slug VARCHAR(40) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT tags_slug_unique UNIQUE (slug)
+);
+
+CREATE TABLE campaign_tags (
+    campaign_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY (campaign_id, tag_id),
+    CONSTRAINT campaign_tags_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE,
+    CONSTRAINT campaign_tags_tag_id_foreign FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_tags_tag_id_index ON campaign_tags (tag_id);
+
+CREATE TABLE rewards (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    campaign_id INTEGER NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    minimum_amount INTEGER NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    reserved_count INTEGER NOT NULL DEFAULT 0,
+    estimated_delivery DATETIME NULL,
+    requires_shipping BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT rewards_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX rewards_campaign_id_index ON rewards (campaign_id);
+
+CREATE TABLE campaign_updates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    campaign_id INTEGER NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT campaign_updates_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_updates_campaign_id_created_at_index ON campaign_updates (campaign_id, created_at);
@@ -0,0 +1,35 @@
+CREATE TABLE transactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    campaign_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    reward_id INTEGER NULL,
+    amount INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    code VARCHAR(100) NOT NULL,
+    payment_url VARCHAR(255) NOT NULL DEFAULT '',
+    refund_status VARCHAR(20) NOT NULL DEFAULT '',
+    refund_attempts INTEGER NOT NULL DEFAULT 0,
+    refund_error TEXT NOT NULL,
+    next_refund_at DATETIME NULL,
+    refunded_at DATETIME NULL,
+    shipping_recipient_name VARCHAR(100) NOT NULL DEFAULT '',
+    shipping_phone VARCHAR(20) NOT NULL DEFAULT '',
+    shipping_street TEXT NOT NULL,
+    shipping_city VARCHAR(100) NOT NULL DEFAULT '',
+    shipping_province VARCHAR(100) NOT NULL DEFAULT '',
+    shipping_postal_code VARCHAR(10) NOT NULL DEFAULT '',
+    shipping_country VARCHAR(100) NOT NULL DEFAULT '',
+    fulfilment_status VARCHAR(20) NOT NULL DEFAULT '',
+    tracking_number VARCHAR(100) NOT NULL DEFAULT '',
+    shipped_at DATETIME NULL,
+    delivered_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT transactions_code_unique UNIQUE (code),
+    CONSTRAINT transactions_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id),
+    CONSTRAINT transactions_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT transactions_reward_id_foreign FOREIGN KEY (reward_id) REFERENCES rewards (id) ON DELETE SET NULL
+);
+CREATE INDEX transactions_campaign_id_status_index ON transactions (campaign_id, status);
+CREATE INDEX transactions_user_id_index ON transactions (user_id);
+CREATE INDEX transactions_refund_status_next_refund_at_index ON transactions (refund_status, next_refund_at);
@@ -0,0 +1,2 @@
+DROP TABLE creator_follows;
+DROP TABLE campaign_follows;
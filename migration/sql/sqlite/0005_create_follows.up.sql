@@ -0,0 +1,21 @@
+CREATE TABLE campaign_follows (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    campaign_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT campaign_follows_user_id_campaign_id_unique UNIQUE (user_id, campaign_id),
+    CONSTRAINT campaign_follows_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT campaign_follows_campaign_id_foreign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE CASCADE
+);
+CREATE INDEX campaign_follows_campaign_id_index ON campaign_follows (campaign_id);
+
+CREATE TABLE creator_follows (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    creator_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT creator_follows_user_id_creator_id_unique UNIQUE (user_id, creator_id),
+    CONSTRAINT creator_follows_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT creator_follows_creator_id_foreign FOREIGN KEY (creator_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX creator_follows_creator_id_index ON creator_follows (creator_id);
@@ -0,0 +1,49 @@
+// Package notification menyediakan penyimpanan dan pengiriman notifikasi ke pengguna.
+package notification
+
+import "time"
+
+// Jenis notifikasi yang dikirim ke pengguna.
+const (
+	TypeNewBacker      = "new_backer"      // Ada backer baru yang lunas bayar di campaign milik pengguna.
+	TypeCampaignFunded = "campaign_funded" // Campaign milik pengguna atau yang didukungnya capai target.
+	TypeCommentReply   = "comment_reply"   // Ada yang bales komentar pengguna.
+	TypeCampaignUpdate = "campaign_update" // Pemilik campaign yang didukung nerbitin kabar terbaru.
+	TypePaymentFailed  = "payment_failed"  // Pembayaran dukungan pengguna gagal atau kadaluarsa.
+)
+
+// Types adalah semua jenis notifikasi, urutannya dipake juga buat nampilin preferensi.
+var Types = []string{TypeNewBacker, TypeCampaignFunded, TypeCommentReply, TypeCampaignUpdate, TypePaymentFailed}
+
+// Notification adalah satu notifikasi buat satu pengguna.
+type Notification struct {
+	ID        int
+	UserID    int
+	Type      string
+	Title     string
+	Body      string
+	Link      string // Path di aplikasi yang dibuka kalo notifikasinya diklik.
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// IsRead ngecek apakah notifikasinya udah dibaca.
+func (n Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationPreference adalah pilihan pengguna buat satu jenis notifikasi.
+// Kalo pengguna belum pernah ngatur, semua jenis dianggap nyala, baik di aplikasi maupun lewat email.
+type NotificationPreference struct {
+	ID        int
+	UserID    int
+	Type      string
+	InApp     bool
+	Email     bool
+	UpdatedAt time.Time
+}
+
+// defaultPreference balikin preferensi bawaan buat satu jenis notifikasi.
+func defaultPreference(userID int, notificationType string) NotificationPreference {
+	return NotificationPreference{UserID: userID, Type: notificationType, InApp: true, Email: true}
+}
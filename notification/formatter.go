@@ -0,0 +1,72 @@
+package notification
+
+import "time"
+
+// NotificationFormatter adalah struktur data buat satu notifikasi.
+type NotificationFormatter struct {
+	ID        int        `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      string     `json:"link"`
+	IsRead    bool       `json:"is_read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// FormatNotification mengonversi data notifikasi menjadi NotificationFormatter.
+func FormatNotification(notification Notification) NotificationFormatter {
+	return NotificationFormatter{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Link:      notification.Link,
+		IsRead:    notification.IsRead(),
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// FormatNotifications mengonversi daftar notifikasi menjadi daftar NotificationFormatter.
+func FormatNotifications(notifications []Notification) []NotificationFormatter {
+	notificationsFormatter := []NotificationFormatter{}
+
+	for _, notification := range notifications {
+		notificationsFormatter = append(notificationsFormatter, FormatNotification(notification))
+	}
+
+	return notificationsFormatter
+}
+
+// UnreadCountFormatter adalah struktur data buat jumlah notifikasi yang belum dibaca.
+type UnreadCountFormatter struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// FormatUnreadCount mengonversi jumlah notifikasi yang belum dibaca menjadi UnreadCountFormatter.
+func FormatUnreadCount(unreadCount int64) UnreadCountFormatter {
+	return UnreadCountFormatter{UnreadCount: unreadCount}
+}
+
+// PreferenceFormatter adalah struktur data buat preferensi satu jenis notifikasi.
+type PreferenceFormatter struct {
+	Type  string `json:"type"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+}
+
+// FormatPreferences mengonversi daftar preferensi notifikasi menjadi daftar PreferenceFormatter.
+func FormatPreferences(preferences []NotificationPreference) []PreferenceFormatter {
+	preferencesFormatter := []PreferenceFormatter{}
+
+	for _, preference := range preferences {
+		preferencesFormatter = append(preferencesFormatter, PreferenceFormatter{
+			Type:  preference.Type,
+			InApp: preference.InApp,
+			Email: preference.Email,
+		})
+	}
+
+	return preferencesFormatter
+}
@@ -0,0 +1,28 @@
+package notification
+
+import "campaignku/user"
+
+// GetNotificationsInput adalah struktur data buat nampung query string saat minta daftar notifikasi.
+type GetNotificationsInput struct {
+	Unread bool `form:"unread"` // true = cuma yang belum dibaca.
+	Page   int  `form:"page" binding:"omitempty,min=1"`
+	Limit  int  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// GetNotificationInput adalah struktur data buat nampung ID notifikasi dari URI.
+type GetNotificationInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// PreferenceInput adalah pilihan baru buat satu jenis notifikasi. Yang nggak diisi tetep pake nilai sebelumnya.
+type PreferenceInput struct {
+	Type  string `json:"type" binding:"required,oneof=new_backer campaign_funded comment_reply campaign_update payment_failed"`
+	InApp *bool  `json:"in_app"`
+	Email *bool  `json:"email"`
+}
+
+// UpdatePreferencesInput adalah struktur data yang digunakan sebagai input saat pengguna ngubah preferensi notifikasinya.
+type UpdatePreferencesInput struct {
+	Preferences []PreferenceInput `json:"preferences" binding:"required,min=1,dive"`
+	User        user.User         // Diisi dari user yang lagi login, bukan dari JSON.
+}
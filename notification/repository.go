@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Banyaknya notifikasi yang disimpen dalam satu query INSERT.
+const saveBatchSize = 100
+
+// Repository adalah interface untuk operasi database notifikasi.
+type Repository interface {
+	SaveMany(ctx context.Context, notifications []Notification) error
+	FindByUserID(ctx context.Context, userID int, unreadOnly bool, page int, limit int) ([]Notification, int64, error)
+	FindByID(ctx context.Context, ID int) (Notification, error)
+	CountUnread(ctx context.Context, userID int) (int64, error)
+	MarkRead(ctx context.Context, notification Notification, now time.Time) (Notification, error)
+	MarkAllRead(ctx context.Context, userID int, now time.Time) (int64, error)
+	FindPreferences(ctx context.Context, userIDs []int, notificationType string) ([]NotificationPreference, error)
+	FindPreferencesByUserID(ctx context.Context, userID int) ([]NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []NotificationPreference) error
+}
+
+// repository adalah implementasi Repository.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository membuat instance Repository dengan koneksi database yang diberikan.
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+// SaveMany menyimpan banyak notifikasi sekaligus, dipecah per batch biar query-nya nggak kegedean.
+func (r *repository) SaveMany(ctx context.Context, notifications []Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range notifications {
+		notifications[i].CreatedAt = now
+	}
+
+	return r.db.WithContext(ctx).CreateInBatches(&notifications, saveBatchSize).Error
+}
+
+// FindByUserID mengambil notifikasi milik pengguna per halaman, yang paling baru duluan.
+// Datanya diambil satu lebih dari limit buat nandain masih ada halaman berikutnya.
+func (r *repository) FindByUserID(ctx context.Context, userID int, unreadOnly bool, page int, limit int) ([]Notification, int64, error) {
+	var notifications []Notification
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return notifications, total, err
+	}
+
+	err = query.Order("created_at DESC, id DESC").Offset((page - 1) * limit).Limit(limit + 1).Find(&notifications).Error
+	if err != nil {
+		return notifications, total, err
+	}
+	return notifications, total, nil
+}
+
+// FindByID mengambil satu notifikasi berdasarkan ID.
+func (r *repository) FindByID(ctx context.Context, ID int) (Notification, error) {
+	var notification Notification
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Find(&notification).Error
+	if err != nil {
+		return notification, err
+	}
+	return notification, nil
+}
+
+// CountUnread menghitung notifikasi pengguna yang belum dibaca.
+func (r *repository) CountUnread(ctx context.Context, userID int) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Model(&Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkRead nandain satu notifikasi udah dibaca. Notifikasi yang udah dibaca sebelumnya nggak diubah waktu bacanya.
+func (r *repository) MarkRead(ctx context.Context, notification Notification, now time.Time) (Notification, error) {
+	if notification.ReadAt != nil {
+		return notification, nil
+	}
+
+	err := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("id = ? AND read_at IS NULL", notification.ID).
+		Update("read_at", now).Error
+	if err != nil {
+		return notification, err
+	}
+
+	notification.ReadAt = &now
+	return notification, nil
+}
+
+// MarkAllRead nandain semua notifikasi pengguna yang belum dibaca jadi udah dibaca, lalu balikin jumlahnya.
+func (r *repository) MarkAllRead(ctx context.Context, userID int, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", now)
+	return result.RowsAffected, result.Error
+}
+
+// FindPreferences mengambil preferensi satu jenis notifikasi milik banyak pengguna.
+// Pengguna yang belum pernah ngatur nggak punya baris di sini.
+func (r *repository) FindPreferences(ctx context.Context, userIDs []int, notificationType string) ([]NotificationPreference, error) {
+	var preferences []NotificationPreference
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("user_id IN ? AND type = ?", userIDs, notificationType).Find(&preferences).Error
+	if err != nil {
+		return preferences, err
+	}
+	return preferences, nil
+}
+
+// FindPreferencesByUserID mengambil semua preferensi notifikasi yang pernah diatur pengguna.
+func (r *repository) FindPreferencesByUserID(ctx context.Context, userID int) ([]NotificationPreference, error) {
+	var preferences []NotificationPreference
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&preferences).Error
+	if err != nil {
+		return preferences, err
+	}
+	return preferences, nil
+}
+
+// SavePreferences nyimpen preferensi notifikasi. Kalo pengguna udah punya baris buat jenis yang sama, barisnya ditimpa.
+func (r *repository) SavePreferences(ctx context.Context, preferences []NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range preferences {
+		preferences[i].UpdatedAt = now
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"in_app", "email", "updated_at"}),
+	}).Create(&preferences).Error
+}
@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"campaignku/helper"
+	"context"
+	"errors"
+	"time"
+)
+
+// Batas default jumlah notifikasi per halaman.
+const defaultLimit = 20
+
+// Error yang bisa dibalikin sama service notifikasi.
+var ErrNotificationNotFound = errors.New("notifikasi tidak ditemukan")
+
+// Service adalah interface untuk layanan notifikasi.
+type Service interface {
+	Notify(ctx context.Context, userIDs []int, notification Notification) error
+	EmailRecipients(ctx context.Context, userIDs []int, notificationType string) ([]int, error)
+	GetNotifications(ctx context.Context, input GetNotificationsInput, userID int) ([]Notification, helper.Pagination, error)
+	CountUnread(ctx context.Context, userID int) (int64, error)
+	MarkAsRead(ctx context.Context, inputID GetNotificationInput, userID int) (Notification, error)
+	MarkAllAsRead(ctx context.Context, userID int) (int64, error)
+	GetPreferences(ctx context.Context, userID int) ([]NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, input UpdatePreferencesInput) ([]NotificationPreference, error)
+}
+
+// service adalah implementasi Service.
+type service struct {
+	repository Repository
+}
+
+// NewService membuat instance Service dengan repository yang diberikan.
+func NewService(repository Repository) *service {
+	return &service{repository}
+}
+
+// Notify mengirim notifikasi yang sama ke banyak pengguna. UserID di notifikasi diisi per penerima.
+// Pengguna yang matiin notifikasi di aplikasi buat jenis ini dilewatin.
+func (s *service) Notify(ctx context.Context, userIDs []int, notification Notification) error {
+	recipients, err := s.filterRecipients(ctx, userIDs, notification.Type, func(preference NotificationPreference) bool {
+		return preference.InApp
+	})
+	if err != nil {
+		return err
+	}
+
+	notifications := make([]Notification, 0, len(recipients))
+	for _, userID := range recipients {
+		recipient := notification
+		recipient.UserID = userID
+		notifications = append(notifications, recipient)
+	}
+
+	return s.repository.SaveMany(ctx, notifications)
+}
+
+// EmailRecipients nyaring pengguna yang masih mau dapet email buat jenis notifikasi ini.
+func (s *service) EmailRecipients(ctx context.Context, userIDs []int, notificationType string) ([]int, error) {
+	return s.filterRecipients(ctx, userIDs, notificationType, func(preference NotificationPreference) bool {
+		return preference.Email
+	})
+}
+
+// filterRecipients nyaring pengguna berdasarkan preferensinya buat satu jenis notifikasi.
+// Pengguna yang belum pernah ngatur preferensi selalu lolos.
+func (s *service) filterRecipients(ctx context.Context, userIDs []int, notificationType string, enabled func(NotificationPreference) bool) ([]int, error) {
+	preferences, err := s.repository.FindPreferences(ctx, userIDs, notificationType)
+	if err != nil {
+		return nil, err
+	}
+
+	disabled := make(map[int]bool, len(preferences))
+	for _, preference := range preferences {
+		if !enabled(preference) {
+			disabled[preference.UserID] = true
+		}
+	}
+
+	recipients := make([]int, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !disabled[userID] {
+			recipients = append(recipients, userID)
+		}
+	}
+	return recipients, nil
+}
+
+// GetNotifications mengambil notifikasi pengguna per halaman, yang paling baru duluan.
+func (s *service) GetNotifications(ctx context.Context, input GetNotificationsInput, userID int) ([]Notification, helper.Pagination, error) {
+	if input.Limit == 0 {
+		input.Limit = defaultLimit
+	}
+	if input.Page == 0 {
+		input.Page = 1
+	}
+
+	pagination := helper.Pagination{Page: input.Page, Limit: input.Limit}
+
+	notifications, total, err := s.repository.FindByUserID(ctx, userID, input.Unread, input.Page, input.Limit)
+	if err != nil {
+		return nil, pagination, err
+	}
+	pagination.Total = total
+
+	// Repository ngambil satu data lebih, jadi kalo kelebihan berarti masih ada halaman berikutnya.
+	if len(notifications) > input.Limit {
+		notifications = notifications[:input.Limit]
+		pagination.HasMore = true
+	}
+
+	return notifications, pagination, nil
+}
+
+// CountUnread menghitung notifikasi pengguna yang belum dibaca.
+func (s *service) CountUnread(ctx context.Context, userID int) (int64, error) {
+	return s.repository.CountUnread(ctx, userID)
+}
+
+// MarkAsRead nandain satu notifikasi milik pengguna udah dibaca.
+// Notifikasi milik pengguna lain dianggap nggak ada, biar ID-nya nggak bisa ditebak-tebak.
+func (s *service) MarkAsRead(ctx context.Context, inputID GetNotificationInput, userID int) (Notification, error) {
+	notification, err := s.repository.FindByID(ctx, inputID.ID)
+	if err != nil {
+		return notification, err
+	}
+	if notification.ID == 0 || notification.UserID != userID {
+		return Notification{}, ErrNotificationNotFound
+	}
+
+	return s.repository.MarkRead(ctx, notification, time.Now())
+}
+
+// MarkAllAsRead nandain semua notifikasi pengguna udah dibaca, lalu balikin berapa yang berubah.
+func (s *service) MarkAllAsRead(ctx context.Context, userID int) (int64, error) {
+	return s.repository.MarkAllRead(ctx, userID, time.Now())
+}
+
+// GetPreferences mengambil preferensi semua jenis notifikasi milik pengguna.
+// Jenis yang belum pernah diatur diisi pake preferensi bawaan.
+func (s *service) GetPreferences(ctx context.Context, userID int) ([]NotificationPreference, error) {
+	saved, err := s.repository.FindPreferencesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[string]NotificationPreference, len(saved))
+	for _, preference := range saved {
+		byType[preference.Type] = preference
+	}
+
+	preferences := make([]NotificationPreference, 0, len(Types))
+	for _, notificationType := range Types {
+		preference, ok := byType[notificationType]
+		if !ok {
+			preference = defaultPreference(userID, notificationType)
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences ngubah preferensi notifikasi pengguna, lalu balikin preferensi lengkapnya.
+func (s *service) UpdatePreferences(ctx context.Context, input UpdatePreferencesInput) ([]NotificationPreference, error) {
+	current, err := s.GetPreferences(ctx, input.User.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[string]NotificationPreference, len(current))
+	for _, preference := range current {
+		byType[preference.Type] = preference
+	}
+
+	// Jenis yang dikirim dobel digabung jadi satu, yang belakangan menang. Kalo nggak, satu query upsert
+	// bakal nyentuh baris yang sama dua kali dan ditolak sama Postgres.
+	changedTypes := []string{}
+	for _, item := range input.Preferences {
+		preference := byType[item.Type]
+		if item.InApp != nil {
+			preference.InApp = *item.InApp
+		}
+		if item.Email != nil {
+			preference.Email = *item.Email
+		}
+		if !containsType(changedTypes, item.Type) {
+			changedTypes = append(changedTypes, item.Type)
+		}
+		byType[item.Type] = preference
+	}
+
+	changed := make([]NotificationPreference, 0, len(changedTypes))
+	for _, notificationType := range changedTypes {
+		changed = append(changed, byType[notificationType])
+	}
+
+	err = s.repository.SavePreferences(ctx, changed)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPreferences(ctx, input.User.ID)
+}
+
+// containsType ngecek jenis notifikasi udah ada di daftar apa belum.
+func containsType(types []string, notificationType string) bool {
+	for _, existing := range types {
+		if existing == notificationType {
+			return true
+		}
+	}
+	return false
+}
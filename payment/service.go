@@ -0,0 +1,236 @@
+// Package payment menyediakan integrasi ke payment gateway (Midtrans) buat bayar transaksi.
+package payment
+
+import (
+	"bytes"
+	"campaignku/user"
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Alamat API Snap dan Core API Midtrans buat sandbox dan production.
+const (
+	sandboxSnapURL    = "https://app.sandbox.midtrans.com/snap/v1/transactions"
+	productionSnapURL = "https://app.midtrans.com/snap/v1/transactions"
+	sandboxCoreURL    = "https://api.sandbox.midtrans.com"
+	productionCoreURL = "https://api.midtrans.com"
+)
+
+// Service mendefinisikan 'kontrak' kerja untuk layanan pembayaran.
+type Service interface {
+	GetPaymentURL(ctx context.Context, transaction Transaction, user user.User) (string, error)   // Fungsi buat bikin halaman bayar dan dapetin URL-nya.
+	VerifySignature(orderID string, statusCode string, grossAmount string, signature string) bool // Fungsi buat cek notifikasi beneran dari Midtrans.
+	Refund(ctx context.Context, transaction Transaction, reason string) error                     // Fungsi buat ngembaliin dana transaksi yang udah lunas.
+	GetStatus(ctx context.Context, code string) (Status, error)                                   // Fungsi buat nanya status terbaru transaksi ke Midtrans.
+	Ping(ctx context.Context) error                                                               // Fungsi buat ngecek Midtrans bisa dihubungin.
+}
+
+// Transaction adalah data transaksi yang dibutuhin payment gateway.
+// Sengaja dipisah dari transaction.Transaction biar package ini nggak tergantung ke package transaction.
+type Transaction struct {
+	Code   string // Kode unik transaksi, dipake sebagai order_id di Midtrans.
+	Amount int    // Jumlah yang harus dibayar.
+}
+
+// Status adalah status transaksi menurut Midtrans. Isinya sama kayak notifikasi pembayaran, termasuk signature-nya.
+// StatusCode "404" artinya transaksinya belum pernah dibayar sama sekali di Midtrans.
+type Status struct {
+	OrderID           string `json:"order_id"`
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	GrossAmount       string `json:"gross_amount"`
+	SignatureKey      string `json:"signature_key"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentType       string `json:"payment_type"`
+}
+
+// midtransService, implementasi dari Service buat Midtrans.
+type midtransService struct {
+	serverKey  string
+	production bool
+	client     *http.Client
+}
+
+// NewService buat instance baru midtransService.
+func NewService(serverKey string, production bool) *midtransService {
+	return &midtransService{
+		serverKey:  serverKey,
+		production: production,
+		client:     &http.Client{Timeout: 15 * time.Second, Transport: otelhttp.NewTransport(http.DefaultTransport)},
+	}
+}
+
+// snapRequest adalah isi request ke API Snap.
+type snapRequest struct {
+	TransactionDetails struct {
+		OrderID     string `json:"order_id"`
+		GrossAmount int    `json:"gross_amount"`
+	} `json:"transaction_details"`
+	CustomerDetails struct {
+		FirstName string `json:"first_name"`
+		Email     string `json:"email"`
+	} `json:"customer_details"`
+}
+
+// snapResponse adalah balasan dari API Snap.
+type snapResponse struct {
+	Token         string   `json:"token"`
+	RedirectURL   string   `json:"redirect_url"`
+	ErrorMessages []string `json:"error_messages"`
+}
+
+// GetPaymentURL bikin transaksi di Midtrans Snap dan balikin URL halaman bayarnya.
+func (s *midtransService) GetPaymentURL(ctx context.Context, transaction Transaction, user user.User) (string, error) {
+	var request snapRequest
+	request.TransactionDetails.OrderID = transaction.Code
+	request.TransactionDetails.GrossAmount = transaction.Amount
+	request.CustomerDetails.FirstName = user.Name
+	request.CustomerDetails.Email = user.Email
+
+	var response snapResponse
+	if err := s.do(ctx, http.MethodPost, s.snapURL(), request, &response); err != nil {
+		return "", err
+	}
+	if response.RedirectURL == "" {
+		return "", fmt.Errorf("midtrans tidak mengembalikan URL pembayaran: %v", response.ErrorMessages)
+	}
+
+	return response.RedirectURL, nil
+}
+
+// VerifySignature ngecek signature_key di notifikasi Midtrans.
+// Rumusnya SHA512(order_id + status_code + gross_amount + server key).
+func (s *midtransService) VerifySignature(orderID string, statusCode string, grossAmount string, signature string) bool {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + s.serverKey))
+	expected := hex.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) == 1
+}
+
+// refundRequest adalah isi request refund ke Core API.
+type refundRequest struct {
+	RefundKey string `json:"refund_key"`
+	Amount    int    `json:"amount"`
+	Reason    string `json:"reason"`
+}
+
+// coreResponse adalah balasan umum dari Core API.
+type coreResponse struct {
+	StatusCode    string `json:"status_code"`
+	StatusMessage string `json:"status_message"`
+}
+
+// Refund minta Midtrans ngembaliin seluruh dana transaksi.
+// refund_key dibikin dari kode transaksi, jadi kalo request-nya diulang Midtrans nggak ngerefund dua kali.
+func (s *midtransService) Refund(ctx context.Context, transaction Transaction, reason string) error {
+	request := refundRequest{
+		RefundKey: transaction.Code + "-refund",
+		Amount:    transaction.Amount,
+		Reason:    reason,
+	}
+
+	var response coreResponse
+	url := fmt.Sprintf("%s/v2/%s/refund", s.coreURL(), transaction.Code)
+	if err := s.do(ctx, http.MethodPost, url, request, &response); err != nil {
+		return err
+	}
+	if response.StatusCode != "200" {
+		return fmt.Errorf("refund ditolak midtrans (%s): %s", response.StatusCode, response.StatusMessage)
+	}
+
+	return nil
+}
+
+// GetStatus nanya status terbaru transaksi ke Core API, misal buat transaksi yang notifikasinya nggak pernah nyampe.
+func (s *midtransService) GetStatus(ctx context.Context, code string) (Status, error) {
+	var status Status
+
+	url := fmt.Sprintf("%s/v2/%s/status", s.coreURL(), code)
+	if err := s.do(ctx, http.MethodGet, url, nil, &status); err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
+
+// Ping ngecek Core API Midtrans bisa dihubungin. Balasan apa pun di bawah 500 dianggap nyambung,
+// soalnya yang dicek cuma jaringan dan layanannya, bukan server key-nya.
+func (s *midtransService) Ping(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, s.coreURL(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("midtrans membalas dengan status %d", response.StatusCode)
+	}
+	return nil
+}
+
+// coreURL milih alamat Core API sesuai mode.
+func (s *midtransService) coreURL() string {
+	if s.production {
+		return productionCoreURL
+	}
+	return sandboxCoreURL
+}
+
+// snapURL milih alamat API Snap sesuai mode.
+func (s *midtransService) snapURL() string {
+	if s.production {
+		return productionSnapURL
+	}
+	return sandboxSnapURL
+}
+
+// do ngirim request ke Midtrans pake server key, terus baca balasannya ke out. Body nil artinya request tanpa isi.
+func (s *midtransService) do(ctx context.Context, method string, url string, body interface{}, out interface{}) error {
+	if s.serverKey == "" {
+		return errors.New("server key midtrans belum diatur")
+	}
+
+	var payload []byte
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = encoded
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(s.serverKey, "")
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("midtrans membalas dengan status %d", response.StatusCode)
+	}
+
+	return json.NewDecoder(response.Body).Decode(out)
+}
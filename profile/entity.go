@@ -0,0 +1,15 @@
+// Package profile menyusun halaman profil publik kreator dari data user, campaign, dan transaksi.
+package profile
+
+import (
+	"campaignku/campaign"
+	"campaignku/user"
+)
+
+// Profile adalah data profil publik seorang kreator.
+type Profile struct {
+	User                user.User
+	Campaigns           []campaign.Campaign
+	TotalRaised         int // Total dana yang terkumpul di semua campaign-nya.
+	BackedCampaignCount int // Jumlah campaign orang lain (atau miliknya) yang udah dia dukung.
+}
@@ -0,0 +1,43 @@
+package profile
+
+import (
+	"campaignku/campaign"
+	"time"
+)
+
+// ProfileFormatter adalah struktur data buat profil publik kreator.
+// Email, role, dan data pribadi lain sengaja nggak ikut ditampilin.
+type ProfileFormatter struct {
+	ID                  int                          `json:"id"`
+	Name                string                       `json:"name"`
+	Occupation          string                       `json:"occupation"`
+	ImageURL            string                       `json:"image_url"`
+	Bio                 string                       `json:"bio"`
+	JoinedAt            time.Time                    `json:"joined_at"`
+	FollowerCount       int                          `json:"follower_count"`
+	TotalRaised         int                          `json:"total_raised"`
+	BackedCampaignCount int                          `json:"backed_campaign_count"`
+	Campaigns           []campaign.CampaignFormatter `json:"campaigns"`
+}
+
+// FormatProfile mengonversi data profil menjadi ProfileFormatter.
+func FormatProfile(profile Profile) ProfileFormatter {
+	formatter := ProfileFormatter{
+		ID:                  profile.User.ID,
+		Name:                profile.User.Name,
+		Occupation:          profile.User.Occupation,
+		ImageURL:            profile.User.AvatarFileName,
+		Bio:                 profile.User.Bio,
+		JoinedAt:            profile.User.CreateAt,
+		FollowerCount:       profile.User.FollowerCount,
+		TotalRaised:         profile.TotalRaised,
+		BackedCampaignCount: profile.BackedCampaignCount,
+		Campaigns:           []campaign.CampaignFormatter{},
+	}
+
+	for _, userCampaign := range profile.Campaigns {
+		formatter.Campaigns = append(formatter.Campaigns, campaign.FormatCampaign(userCampaign))
+	}
+
+	return formatter
+}
@@ -0,0 +1,6 @@
+package profile
+
+// GetProfileInput adalah struktur data buat nampung ID user dari URI.
+type GetProfileInput struct {
+	ID int `uri:"id" binding:"required"`
+}
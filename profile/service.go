@@ -0,0 +1,62 @@
+package profile
+
+import (
+	"campaignku/campaign"
+	"campaignku/transaction"
+	"campaignku/user"
+	"context"
+	"errors"
+)
+
+// ErrProfileNotFound dibalikin kalo user dengan ID yang diminta nggak ada.
+var ErrProfileNotFound = errors.New("profil tidak ditemukan")
+
+// Service adalah interface yang mendefinisikan fungsi yang harus ada di service profil.
+type Service interface {
+	GetProfile(ctx context.Context, input GetProfileInput) (Profile, error) // Fungsi buat dapetin profil publik seorang kreator.
+}
+
+// service adalah struct yang implementasi dari Service.
+type service struct {
+	userRepository        user.Repository
+	campaignRepository    campaign.Repository
+	transactionRepository transaction.Repository
+}
+
+// NewService adalah fungsi pembuat service baru.
+func NewService(userRepository user.Repository, campaignRepository campaign.Repository, transactionRepository transaction.Repository) *service {
+	return &service{userRepository, campaignRepository, transactionRepository}
+}
+
+// GetProfile adalah method dari service buat nyusun profil publik kreator:
+// data dirinya, campaign-campaign-nya, total dana terkumpul, dan jumlah campaign yang dia dukung.
+func (s *service) GetProfile(ctx context.Context, input GetProfileInput) (Profile, error) {
+	profile := Profile{}
+
+	creator, err := s.userRepository.FindByID(ctx, input.ID)
+	if err != nil {
+		return profile, err
+	}
+	if creator.ID == 0 {
+		return profile, ErrProfileNotFound
+	}
+	profile.User = creator
+
+	campaigns, err := s.campaignRepository.FindByUserID(ctx, creator.ID)
+	if err != nil {
+		return profile, err
+	}
+	profile.Campaigns = campaigns
+
+	for _, creatorCampaign := range campaigns {
+		profile.TotalRaised += creatorCampaign.CurrentAmount
+	}
+
+	backedCount, err := s.transactionRepository.CountBackedCampaigns(ctx, creator.ID)
+	if err != nil {
+		return profile, err
+	}
+	profile.BackedCampaignCount = int(backedCount)
+
+	return profile, nil
+}
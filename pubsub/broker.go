@@ -0,0 +1,38 @@
+// Package pubsub menyediakan saluran publish/subscribe buat nyebarin event di dalam aplikasi.
+// Implementasi bawaan hidup di memori satu proses. Kalo aplikasinya udah jalan di banyak instance,
+// ganti pake implementasi yang nyambung ke broker bersama (misal Redis) tanpa ngubah pemakainya.
+package pubsub
+
+import "context"
+
+// Event adalah satu pesan yang dikirim ke sebuah topik. Payload-nya berupa byte (biasanya JSON)
+// biar gampang dikirim lewat broker di luar proses.
+type Event struct {
+	Type    string
+	UserID  int // Pemilik event, misal pemilik campaign yang dapet dukungan. 0 kalo event-nya nggak punya pemilik.
+	Payload []byte
+}
+
+// Handler adalah fungsi yang dijalanin buat tiap event di sebuah topik.
+// Context-nya diterusin dari pengirim event, jadi handler ikut berhenti kalo request pengirimnya dibatalin.
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher adalah interface buat ngirim event ke sebuah topik.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, event Event) error
+}
+
+// Subscriber adalah interface buat nerima event dari sebuah topik.
+// Fungsi yang dibalikin harus dipanggil buat berhenti langganan, setelah itu channel-nya ditutup.
+type Subscriber interface {
+	Subscribe(topic string) (<-chan Event, func())
+}
+
+// Broker adalah gabungan Publisher dan Subscriber.
+// Beda sama Subscribe yang boleh ketinggalan event, Handler yang didaftarin lewat Handle
+// dijalanin buat tiap event dan error-nya dibalikin ke pengirim.
+type Broker interface {
+	Publisher
+	Subscriber
+	Handle(topic string, handler Handler)
+}
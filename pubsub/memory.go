@@ -0,0 +1,93 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// memoryBroker adalah Broker yang hidup di memori satu proses.
+type memoryBroker struct {
+	mu          sync.RWMutex
+	buffer      int
+	subscribers map[string]map[*subscription]struct{}
+	handlers    map[string][]Handler
+}
+
+// subscription adalah satu langganan ke sebuah topik.
+type subscription struct {
+	events chan Event
+	once   sync.Once
+}
+
+// NewMemoryBroker bikin Broker di memori. Tiap pelanggan dapet antrian sebanyak buffer event;
+// kalo antriannya penuh (pelanggannya lambat), event baru buat pelanggan itu dibuang biar pengirimnya nggak ikut ketahan.
+func NewMemoryBroker(buffer int) *memoryBroker {
+	return &memoryBroker{
+		buffer:      buffer,
+		subscribers: make(map[string]map[*subscription]struct{}),
+		handlers:    make(map[string][]Handler),
+	}
+}
+
+// Publish ngirim event ke semua pelanggan topik itu.
+// Handler dijalanin langsung satu per satu, error-nya digabung dan dibalikin.
+func (b *memoryBroker) Publish(ctx context.Context, topic string, event Event) error {
+	// Handler dijalanin di luar kunci, biar handler yang ngirim event lagi nggak bikin deadlock.
+	b.mu.RLock()
+	handlers := b.handlers[topic]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[topic] {
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Handle ndaftarin handler buat sebuah topik.
+func (b *memoryBroker) Handle(topic string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[topic] = append(b.handlers[topic], handler)
+}
+
+// Subscribe mulai langganan ke sebuah topik.
+func (b *memoryBroker) Subscribe(topic string) (<-chan Event, func()) {
+	sub := &subscription{events: make(chan Event, b.buffer)}
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[*subscription]struct{})
+	}
+	b.subscribers[topic][sub] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		sub.once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[topic], sub)
+			if len(b.subscribers[topic]) == 0 {
+				delete(b.subscribers, topic)
+			}
+			b.mu.Unlock()
+
+			close(sub.events)
+		})
+	}
+
+	return sub.events, unsubscribe
+}
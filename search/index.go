@@ -0,0 +1,24 @@
+// Package search menyediakan pencarian teks penuh buat campaign.
+// Pencarian dibungkus di balik interface Index, jadi implementasi bawaan yang jalan di memori
+// bisa diganti ke cluster pencarian eksternal tanpa ngubah service yang manggilnya.
+package search
+
+// Document adalah data yang dimasukin ke index.
+type Document struct {
+	ID     int               // ID data aslinya, misal ID campaign.
+	Fields map[string]string // Isi tiap field yang bisa dicari, key-nya nama field.
+}
+
+// Result adalah satu hasil pencarian.
+type Result struct {
+	ID         int               // ID dokumen yang cocok.
+	Score      float64           // Skor relevansi, makin besar makin relevan.
+	Highlights map[string]string // Potongan teks per field, kata yang cocok dibungkus <mark>.
+}
+
+// Index adalah 'kontrak' kerja untuk mesin pencarian.
+type Index interface {
+	Index(doc Document) error                         // Fungsi buat nambah atau nimpa dokumen di index.
+	Delete(id int) error                              // Fungsi buat ngapus dokumen dari index.
+	Search(query string, limit int) ([]Result, error) // Fungsi buat nyari dokumen, hasilnya urut dari yang paling relevan.
+}
@@ -0,0 +1,369 @@
+package search
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Parameter BM25 yang umum dipake.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Bobot skor buat tiap jenis kecocokan kata.
+const (
+	exactWeight  = 1.0
+	prefixWeight = 0.8
+	typoWeight   = 0.5
+)
+
+// Panjang potongan teks (dalam rune) di kiri dan kanan kata yang cocok.
+const snippetRadius = 60
+
+// posting nyimpen berapa kali sebuah kata muncul di tiap field satu dokumen.
+type posting map[string]int
+
+// memoryIndex adalah implementasi Index yang nyimpen semuanya di memori proses.
+type memoryIndex struct {
+	mu       sync.RWMutex
+	weights  map[string]float64         // Bobot tiap field, field yang nggak ada di sini nggak diindex.
+	docs     map[int]Document           // Dokumen asli, dipake buat bikin potongan teks.
+	lengths  map[int]map[string]int     // Jumlah kata per field per dokumen.
+	postings map[string]map[int]posting // Kata -> dokumen -> field -> jumlah kemunculan.
+	totals   map[string]int             // Total kata per field di semua dokumen, buat rata-rata panjang.
+}
+
+// NewMemoryIndex bikin index di memori. weights nentuin field apa aja yang diindex dan seberapa penting.
+func NewMemoryIndex(weights map[string]float64) *memoryIndex {
+	return &memoryIndex{
+		weights:  weights,
+		docs:     map[int]Document{},
+		lengths:  map[int]map[string]int{},
+		postings: map[string]map[int]posting{},
+		totals:   map[string]int{},
+	}
+}
+
+// Index nambah dokumen ke index. Kalo ID-nya udah ada, dokumen lama ditimpa.
+func (idx *memoryIndex) Index(doc Document) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(doc.ID)
+
+	lengths := map[string]int{}
+	for field, text := range doc.Fields {
+		if _, ok := idx.weights[field]; !ok {
+			continue
+		}
+
+		tokens := tokenize(text)
+		lengths[field] = len(tokens)
+		idx.totals[field] += len(tokens)
+
+		for _, token := range tokens {
+			docs, ok := idx.postings[token.term]
+			if !ok {
+				docs = map[int]posting{}
+				idx.postings[token.term] = docs
+			}
+			if docs[doc.ID] == nil {
+				docs[doc.ID] = posting{}
+			}
+			docs[doc.ID][field]++
+		}
+	}
+
+	idx.docs[doc.ID] = doc
+	idx.lengths[doc.ID] = lengths
+	return nil
+}
+
+// Delete ngapus dokumen dari index.
+func (idx *memoryIndex) Delete(id int) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+	return nil
+}
+
+// remove ngapus dokumen tanpa ngunci, pemanggil wajib udah pegang lock.
+func (idx *memoryIndex) remove(id int) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	for field, text := range doc.Fields {
+		if _, ok := idx.weights[field]; !ok {
+			continue
+		}
+		for _, token := range tokenize(text) {
+			if docs, ok := idx.postings[token.term]; ok {
+				delete(docs, id)
+				if len(docs) == 0 {
+					delete(idx.postings, token.term)
+				}
+			}
+		}
+		idx.totals[field] -= idx.lengths[id][field]
+	}
+
+	delete(idx.docs, id)
+	delete(idx.lengths, id)
+}
+
+// Search nyari dokumen yang cocok sama query.
+// Tiap kata di query dicocokin persis, sebagai awalan kata, atau dengan toleransi salah ketik,
+// terus skornya dihitung pake BM25 per field dikali bobot field-nya.
+func (idx *memoryIndex) Search(query string, limit int) ([]Result, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	queryTokens := tokenize(query)
+	if len(queryTokens) == 0 || len(idx.docs) == 0 {
+		return []Result{}, nil
+	}
+
+	scores := map[int]float64{}
+	matchedTokens := map[int]int{}
+	matchedTerms := map[int]map[string]bool{}
+
+	for _, queryToken := range queryTokens {
+		seen := map[int]bool{}
+
+		for term, weight := range idx.expand(queryToken.term) {
+			docs := idx.postings[term]
+			idf := math.Log(1 + (float64(len(idx.docs))-float64(len(docs))+0.5)/(float64(len(docs))+0.5))
+
+			for id, fields := range docs {
+				for field, frequency := range fields {
+					scores[id] += weight * idx.weights[field] * idf * idx.bm25(id, field, frequency)
+				}
+				if matchedTerms[id] == nil {
+					matchedTerms[id] = map[string]bool{}
+				}
+				matchedTerms[id][term] = true
+				seen[id] = true
+			}
+		}
+
+		for id := range seen {
+			matchedTokens[id]++
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		// Dokumen yang cocok sama lebih banyak kata di query dapet skor lebih tinggi.
+		coverage := float64(matchedTokens[id]) / float64(len(queryTokens))
+		results = append(results, Result{
+			ID:         id,
+			Score:      score * coverage * coverage,
+			Highlights: idx.highlight(idx.docs[id], matchedTerms[id]),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].ID > results[j].ID
+		}
+		return results[i].Score > results[j].Score
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// bm25 ngitung skor BM25 (tanpa IDF) buat satu field di satu dokumen.
+func (idx *memoryIndex) bm25(id int, field string, frequency int) float64 {
+	average := float64(idx.totals[field]) / float64(len(idx.docs))
+	if average == 0 {
+		average = 1
+	}
+	length := float64(idx.lengths[id][field])
+	tf := float64(frequency)
+
+	return tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/average))
+}
+
+// expand nyari semua kata di index yang dianggap cocok sama satu kata dari query, beserta bobotnya.
+func (idx *memoryIndex) expand(term string) map[string]float64 {
+	terms := map[string]float64{}
+	if _, ok := idx.postings[term]; ok {
+		terms[term] = exactWeight
+	}
+
+	maxDistance := typoTolerance(term)
+	for candidate := range idx.postings {
+		if candidate == term {
+			continue
+		}
+		if len([]rune(term)) >= 3 && strings.HasPrefix(candidate, term) {
+			terms[candidate] = prefixWeight
+			continue
+		}
+		if maxDistance > 0 && levenshtein(term, candidate, maxDistance) <= maxDistance {
+			terms[candidate] = typoWeight
+		}
+	}
+
+	return terms
+}
+
+// highlight bikin potongan teks per field dengan kata yang cocok dibungkus <mark>.
+// Teks di luar tag <mark> udah di-escape, jadi aman ditampilin sebagai HTML.
+func (idx *memoryIndex) highlight(doc Document, terms map[string]bool) map[string]string {
+	highlights := map[string]string{}
+
+	for field, text := range doc.Fields {
+		if _, ok := idx.weights[field]; !ok {
+			continue
+		}
+
+		runes := []rune(text)
+		var matches []token
+		for _, token := range tokenize(text) {
+			if terms[token.term] {
+				matches = append(matches, token)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		// Potongan teks diambil di sekitar kata pertama yang cocok.
+		start := matches[0].start - snippetRadius
+		if start < 0 {
+			start = 0
+		}
+		end := matches[0].end + snippetRadius
+		if end > len(runes) {
+			end = len(runes)
+		}
+
+		var builder strings.Builder
+		if start > 0 {
+			builder.WriteString("…")
+		}
+		cursor := start
+		for _, match := range matches {
+			if match.start < cursor || match.end > end {
+				continue
+			}
+			builder.WriteString(html.EscapeString(string(runes[cursor:match.start])))
+			builder.WriteString("<mark>")
+			builder.WriteString(html.EscapeString(string(runes[match.start:match.end])))
+			builder.WriteString("</mark>")
+			cursor = match.end
+		}
+		builder.WriteString(html.EscapeString(string(runes[cursor:end])))
+		if end < len(runes) {
+			builder.WriteString("…")
+		}
+
+		highlights[field] = builder.String()
+	}
+
+	return highlights
+}
+
+// token adalah satu kata hasil pemotongan teks, lengkap sama posisinya (dalam rune).
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize motong teks jadi kata-kata huruf kecil. Semua selain huruf dan angka dianggap pemisah.
+func tokenize(text string) []token {
+	var tokens []token
+	runes := []rune(text)
+
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{
+				term:  strings.ToLower(string(runes[start:i])),
+				start: start,
+				end:   i,
+			})
+			start = -1
+		}
+	}
+
+	return tokens
+}
+
+// typoTolerance nentuin berapa banyak salah ketik yang ditoleransi sesuai panjang kata.
+func typoTolerance(term string) int {
+	length := len([]rune(term))
+	switch {
+	case length >= 8:
+		return 2
+	case length >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// levenshtein ngitung jarak edit antara dua kata. Perhitungan berhenti lebih awal
+// kalo jaraknya pasti udah lewat dari max, hasilnya max+1.
+func levenshtein(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		smallest := current[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if current[j] < smallest {
+				smallest = current[j]
+			}
+		}
+		if smallest > max {
+			return max + 1
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// minInt balikin angka paling kecil dari beberapa angka.
+func minInt(values ...int) int {
+	smallest := values[0]
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+	return smallest
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"campaignku/config"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Fungsi buat bikin http.Server sesuai konfigurasi. Beda sama router.Run, server ini punya batas waktu
+// baca/tulis/nganggur biar koneksi yang lambat atau nyangkut nggak nahan resource selamanya.
+func newHTTPServer(cfg config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}
+
+// Fungsi buat jalanin server sampe dimatiin. Kalo file TLS-nya diisi, server jalan pake HTTPS
+// dan sertifikatnya dibaca ulang tiap kali filenya berubah, jadi perpanjang sertifikat nggak perlu restart.
+// Balikin nil kalo server berhenti gara-gara Shutdown.
+func serve(server *http.Server, cfg config.ServerConfig) error {
+	var err error
+	if cfg.TLSEnabled() {
+		reloader, loadErr := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if loadErr != nil {
+			return loadErr
+		}
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// certReloader nyimpen sertifikat TLS yang lagi dipake dan baca ulang filenya kalo ada yang berubah.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// Fungsi buat bikin certReloader sekaligus baca sertifikatnya pertama kali.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Fungsi yang dipanggil tiap handshake TLS. Kalo file sertifikat atau key-nya lebih baru dari yang kebaca,
+// sertifikatnya dibaca ulang. Kalo gagal (misal filenya baru ketulis setengah), sertifikat lama tetep dipake.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		slog.Warn("gagal ngecek file sertifikat TLS, pake yang lama", "error", err)
+		return r.cert, nil
+	}
+
+	if modTime.After(r.modTime) {
+		if err := r.load(modTime); err != nil {
+			slog.Warn("gagal baca ulang sertifikat TLS, pake yang lama", "error", err)
+		} else {
+			slog.Info("sertifikat TLS dibaca ulang", "file", r.certFile)
+		}
+	}
+
+	return r.cert, nil
+}
+
+// Fungsi buat baca pasangan sertifikat dan key dari file.
+func (r *certReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("gagal baca sertifikat TLS: %w", err)
+	}
+
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+// Fungsi buat dapetin waktu ubah paling baru dari file sertifikat dan key.
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// Fungsi middleware buat batalin context request begitu server mulai dimatiin.
+// Dipake buat request yang jalan terus kayak stream, soalnya Shutdown nungguin request selesai sendiri.
+func closeOnShutdown(shutdown context.Context) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		stop := context.AfterFunc(shutdown, cancel)
+		defer stop()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// Fungsi buat nunggu sesuatu selesai, tapi nyerah kalo context-nya udah lewat batas waktu.
+func waitUntil(ctx context.Context, name string, wait func()) {
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("gagal nunggu sampe selesai", "name", name, "error", ctx.Err())
+	}
+}
+
+// Fungsi buat nutup pool koneksi database.
+func closeDatabase(db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		slog.Error("gagal nutup koneksi database", "error", err)
+		return
+	}
+	if err := sqlDB.Close(); err != nil {
+		slog.Error("gagal nutup koneksi database", "error", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Package tracing nyiapin tracing OpenTelemetry: tracer provider sesuai konfigurasi, propagasi W3C trace-context,
+// helper buat bikin span, dan plugin GORM buat nge-trace query database.
+// Tracer provider-nya dipasang global, jadi package lain cukup manggil Start tanpa perlu dioper provider-nya.
+package tracing
+
+import (
+	"campaignku/config"
+	"context"
+	"errors"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName adalah nama tracer buat span yang dibikin aplikasi sendiri.
+const instrumentationName = "campaignku"
+
+// Setup masang tracer provider global sesuai konfigurasi dan propagator W3C trace-context + baggage.
+// Balikin fungsi buat ngirim sisa span dan nutup exporter-nya, dipanggil pas aplikasi dimatiin.
+// Exporter "none" tetep masang propagator, jadi trace dari upstream tetep diterusin ke panggilan keluar.
+func Setup(ctx context.Context, cfg config.TracingConfig, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case config.TracingExporterStdout:
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, err
+		}
+		exporter = stdoutExporter
+	case config.TracingExporterOTLP:
+		otlpExporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		exporter = otlpExporter
+	default:
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start bikin span baru di bawah span yang ada di ctx. Fungsi yang dibalikin wajib dipanggil buat nutup span-nya,
+// error yang dioper (kalo ada) dicatat di span-nya.
+//
+//	ctx, end := tracing.Start(ctx, "user.Login")
+//	defer func() { end(err) }()
+func Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, func(err error)) {
+	ctx, span := otelTracer().Start(ctx, name, options...)
+	return ctx, func(err error) {
+		RecordError(span, err)
+		span.End()
+	}
+}
+
+// otelTracer dapetin tracer aplikasi dari tracer provider global. Diambil tiap kali dipake
+// biar tetep kena provider yang dipasang Setup walau dipanggil sebelum Setup jalan.
+func otelTracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// RecordError nyatet error di span dan nandain span-nya gagal. Error nil nggak ngapa-ngapain.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,76 @@
+package transaction
+
+import (
+	"campaignku/campaign"
+	"campaignku/user"
+	"time"
+)
+
+// Status transaksi. Transaksi mulai dari pending sampai dibayar (paid) atau batal (cancelled).
+const (
+	StatusPending   = "pending"
+	StatusPaid      = "paid"
+	StatusCancelled = "cancelled"
+	StatusRefunded  = "refunded"
+)
+
+// Status refund transaksi. Kosong artinya transaksi nggak perlu direfund.
+const (
+	RefundPending   = "pending"   // Antri buat direfund, dicoba lagi kalo gagal.
+	RefundSucceeded = "succeeded" // Dana udah dikembalikan ke backer.
+	RefundFailed    = "failed"    // Udah nyerah setelah beberapa kali gagal, perlu dicek manual.
+)
+
+// Status pengiriman reward ke backer.
+const (
+	FulfilmentPending   = "pending"
+	FulfilmentShipped   = "shipped"
+	FulfilmentDelivered = "delivered"
+)
+
+// ShippingAddress adalah salinan alamat pengiriman pada saat transaksi dibuat.
+// Disalin, bukan direferensikan, biar alamatnya nggak ikut berubah kalo buku alamat backer diubah.
+type ShippingAddress struct {
+	RecipientName string
+	Phone         string
+	Street        string
+	City          string
+	Province      string
+	PostalCode    string
+	Country       string
+}
+
+// Transaction adalah struktur data yang merepresentasikan dukungan dana dari backer ke sebuah campaign.
+type Transaction struct {
+	ID               int
+	CampaignID       int
+	UserID           int
+	RewardID         *int
+	Amount           int
+	Status           string
+	Code             string
+	PaymentURL       string
+	RefundStatus     string
+	RefundAttempts   int
+	RefundError      string
+	NextRefundAt     *time.Time
+	RefundedAt       *time.Time
+	Shipping         ShippingAddress `gorm:"embedded;embeddedPrefix:shipping_"`
+	FulfilmentStatus string
+	TrackingNumber   string
+	ShippedAt        *time.Time
+	DeliveredAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	User             user.User
+	Campaign         campaign.Campaign
+	Reward           *campaign.Reward
+}
+
+// Progress adalah kondisi pendanaan sebuah campaign yang dikirim real-time ke halaman campaign.
+// RecentBackers diisi buat data awal waktu baru nyambung, Backer diisi kalo progress-nya berubah karena ada dukungan baru.
+type Progress struct {
+	Campaign      campaign.Campaign
+	RecentBackers []Transaction
+	Backer        *Transaction
+}
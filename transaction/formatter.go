@@ -0,0 +1,244 @@
+// Package transaction menyediakan representasi data dan operasi layanan untuk transaksi dukungan campaign.
+package transaction
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+)
+
+// TransactionFormatter adalah struktur data yang digunakan untuk memformat data transaksi sebelum dikirim sebagai respons API.
+type TransactionFormatter struct {
+	ID               int        `json:"id"`
+	CampaignID       int        `json:"campaign_id"`
+	UserID           int        `json:"user_id"`
+	RewardID         *int       `json:"reward_id"`
+	Amount           int        `json:"amount"`
+	Status           string     `json:"status"`
+	Code             string     `json:"code"`
+	PaymentURL       string     `json:"payment_url"`
+	RefundStatus     string     `json:"refund_status"`
+	RefundedAt       *time.Time `json:"refunded_at"`
+	FulfilmentStatus string     `json:"fulfilment_status"`
+	TrackingNumber   string     `json:"tracking_number"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// FormatTransaction mengonversi data transaksi menjadi TransactionFormatter.
+func FormatTransaction(transaction Transaction) TransactionFormatter {
+	formatter := TransactionFormatter{
+		ID:               transaction.ID,
+		CampaignID:       transaction.CampaignID,
+		UserID:           transaction.UserID,
+		RewardID:         transaction.RewardID,
+		Amount:           transaction.Amount,
+		Status:           transaction.Status,
+		Code:             transaction.Code,
+		PaymentURL:       transaction.PaymentURL,
+		RefundStatus:     transaction.RefundStatus,
+		RefundedAt:       transaction.RefundedAt,
+		FulfilmentStatus: transaction.FulfilmentStatus,
+		TrackingNumber:   transaction.TrackingNumber,
+		CreatedAt:        transaction.CreatedAt,
+	}
+
+	return formatter
+}
+
+// FormatTransactions mengonversi daftar transaksi menjadi daftar TransactionFormatter.
+func FormatTransactions(transactions []Transaction) []TransactionFormatter {
+	transactionsFormatter := []TransactionFormatter{}
+
+	for _, transaction := range transactions {
+		transactionsFormatter = append(transactionsFormatter, FormatTransaction(transaction))
+	}
+
+	return transactionsFormatter
+}
+
+// FulfilmentFormatter adalah struktur data buat satu baris daftar pengiriman reward yang diliat pemilik campaign.
+type FulfilmentFormatter struct {
+	TransactionID  int                       `json:"transaction_id"`
+	Code           string                    `json:"code"`
+	BackerName     string                    `json:"backer_name"`
+	RewardID       *int                      `json:"reward_id"`
+	RewardTitle    string                    `json:"reward_title"`
+	Amount         int                       `json:"amount"`
+	Shipping       *ShippingAddressFormatter `json:"shipping"`
+	Status         string                    `json:"status"`
+	TrackingNumber string                    `json:"tracking_number"`
+	ShippedAt      *time.Time                `json:"shipped_at"`
+	DeliveredAt    *time.Time                `json:"delivered_at"`
+}
+
+// ShippingAddressFormatter adalah alamat pengiriman yang disalin ke transaksi.
+type ShippingAddressFormatter struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+	Country       string `json:"country"`
+}
+
+// FormatFulfilment mengonversi data transaksi menjadi FulfilmentFormatter.
+// Shipping bernilai null kalo reward-nya nggak perlu dikirim.
+func FormatFulfilment(transaction Transaction) FulfilmentFormatter {
+	formatter := FulfilmentFormatter{
+		TransactionID:  transaction.ID,
+		Code:           transaction.Code,
+		BackerName:     transaction.User.Name,
+		RewardID:       transaction.RewardID,
+		Amount:         transaction.Amount,
+		Status:         transaction.FulfilmentStatus,
+		TrackingNumber: transaction.TrackingNumber,
+		ShippedAt:      transaction.ShippedAt,
+		DeliveredAt:    transaction.DeliveredAt,
+	}
+
+	if transaction.Reward != nil {
+		formatter.RewardTitle = transaction.Reward.Title
+	}
+
+	if transaction.Shipping != (ShippingAddress{}) {
+		formatter.Shipping = &ShippingAddressFormatter{
+			RecipientName: transaction.Shipping.RecipientName,
+			Phone:         transaction.Shipping.Phone,
+			Street:        transaction.Shipping.Street,
+			City:          transaction.Shipping.City,
+			Province:      transaction.Shipping.Province,
+			PostalCode:    transaction.Shipping.PostalCode,
+			Country:       transaction.Shipping.Country,
+		}
+	}
+
+	return formatter
+}
+
+// FormatFulfilments mengonversi daftar transaksi menjadi daftar FulfilmentFormatter.
+func FormatFulfilments(transactions []Transaction) []FulfilmentFormatter {
+	fulfilmentsFormatter := []FulfilmentFormatter{}
+
+	for _, transaction := range transactions {
+		fulfilmentsFormatter = append(fulfilmentsFormatter, FormatFulfilment(transaction))
+	}
+
+	return fulfilmentsFormatter
+}
+
+// WriteShippingCSV nulis daftar pengiriman dalam format CSV, satu baris per transaksi yang reward-nya perlu dikirim.
+// Email backer sengaja nggak ikut ditulis, cukup data yang dibutuhin buat ngirim paket.
+func WriteShippingCSV(w io.Writer, transactions []Transaction) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		"transaction_code", "backer_name", "reward", "recipient_name", "phone", "street",
+		"city", "province", "postal_code", "country", "status", "tracking_number",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		if transaction.Reward == nil || !transaction.Reward.RequiresShipping {
+			continue
+		}
+
+		record := []string{
+			transaction.Code,
+			transaction.User.Name,
+			transaction.Reward.Title,
+			transaction.Shipping.RecipientName,
+			transaction.Shipping.Phone,
+			transaction.Shipping.Street,
+			transaction.Shipping.City,
+			transaction.Shipping.Province,
+			transaction.Shipping.PostalCode,
+			transaction.Shipping.Country,
+			transaction.FulfilmentStatus,
+			transaction.TrackingNumber,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ProgressFormatter adalah struktur data buat progress pendanaan campaign yang dikirim lewat stream.
+type ProgressFormatter struct {
+	CampaignID    int               `json:"campaign_id"`
+	CurrentAmount int               `json:"current_amount"`
+	BackerCount   int               `json:"backer_count"`
+	PercentFunded float64           `json:"percent_funded"`
+	RecentBackers []BackerFormatter `json:"recent_backers,omitempty"`
+	Backer        *BackerFormatter  `json:"backer,omitempty"`
+}
+
+// BackerFormatter adalah data satu dukungan yang boleh ditampilin ke publik.
+type BackerFormatter struct {
+	Name     string    `json:"name"`
+	ImageURL string    `json:"image_url"`
+	Amount   int       `json:"amount"`
+	BackedAt time.Time `json:"backed_at"`
+}
+
+// FormatProgress mengonversi progress pendanaan menjadi ProgressFormatter.
+func FormatProgress(progress Progress) ProgressFormatter {
+	formatter := ProgressFormatter{
+		CampaignID:    progress.Campaign.ID,
+		CurrentAmount: progress.Campaign.CurrentAmount,
+		BackerCount:   progress.Campaign.BackerCount,
+		PercentFunded: progress.Campaign.PercentFunded(),
+	}
+
+	for _, transaction := range progress.RecentBackers {
+		formatter.RecentBackers = append(formatter.RecentBackers, formatBacker(transaction))
+	}
+
+	if progress.Backer != nil {
+		backer := formatBacker(*progress.Backer)
+		formatter.Backer = &backer
+	}
+
+	return formatter
+}
+
+// formatBacker ngambil data publik dari sebuah transaksi lunas.
+func formatBacker(transaction Transaction) BackerFormatter {
+	return BackerFormatter{
+		Name:     transaction.User.Name,
+		ImageURL: transaction.User.AvatarFileName,
+		Amount:   transaction.Amount,
+		BackedAt: transaction.UpdatedAt,
+	}
+}
+
+// PaidEventFormatter adalah isi event transaction.paid yang dikirim ke pemilik campaign.
+type PaidEventFormatter struct {
+	TransactionID int       `json:"transaction_id"`
+	Code          string    `json:"code"`
+	CampaignID    int       `json:"campaign_id"`
+	CampaignName  string    `json:"campaign_name"`
+	RewardID      *int      `json:"reward_id"`
+	Amount        int       `json:"amount"`
+	BackerName    string    `json:"backer_name"`
+	PaidAt        time.Time `json:"paid_at"`
+}
+
+// FormatPaidEvent mengonversi transaksi yang baru lunas menjadi PaidEventFormatter.
+func FormatPaidEvent(transaction Transaction) PaidEventFormatter {
+	return PaidEventFormatter{
+		TransactionID: transaction.ID,
+		Code:          transaction.Code,
+		CampaignID:    transaction.CampaignID,
+		CampaignName:  transaction.Campaign.Name,
+		RewardID:      transaction.RewardID,
+		Amount:        transaction.Amount,
+		BackerName:    transaction.User.Name,
+		PaidAt:        transaction.UpdatedAt,
+	}
+}
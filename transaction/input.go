@@ -0,0 +1,41 @@
+package transaction
+
+import "campaignku/user"
+
+// CreateTransactionInput adalah struktur data yang digunakan sebagai input saat backer mendukung campaign.
+type CreateTransactionInput struct {
+	CampaignID int       `json:"campaign_id" binding:"required"`
+	Amount     int       `json:"amount" binding:"required,min=1"`
+	RewardID   *int      `json:"reward_id"`  // Opsional, reward yang dipilih backer.
+	AddressID  *int      `json:"address_id"` // Wajib kalo reward-nya perlu dikirim.
+	User       user.User // Diisi dari user yang lagi login, bukan dari JSON.
+}
+
+// TransactionNotificationInput adalah struktur data notifikasi pembayaran yang dikirim Midtrans.
+type TransactionNotificationInput struct {
+	TransactionStatus string `json:"transaction_status"`
+	OrderID           string `json:"order_id"`
+	PaymentType       string `json:"payment_type"`
+	FraudStatus       string `json:"fraud_status"`
+	StatusCode        string `json:"status_code"`
+	GrossAmount       string `json:"gross_amount"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+// GetCampaignFulfilmentsInput adalah struktur data buat nampung ID campaign dari URI.
+type GetCampaignFulfilmentsInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// FulfilmentItemInput adalah perubahan status pengiriman satu transaksi.
+type FulfilmentItemInput struct {
+	TransactionID  int    `json:"transaction_id" binding:"required"`
+	Status         string `json:"status" binding:"required,oneof=pending shipped delivered"`
+	TrackingNumber string `json:"tracking_number" binding:"max=100"`
+}
+
+// UpdateFulfilmentsInput adalah struktur data yang digunakan pemilik campaign buat ngubah status pengiriman banyak transaksi sekaligus.
+type UpdateFulfilmentsInput struct {
+	Items []FulfilmentItemInput `json:"items" binding:"required,min=1,max=500,dive"`
+	User  user.User             // Diisi dari user yang lagi login, bukan dari JSON.
+}
@@ -0,0 +1,253 @@
+package transaction
+
+import (
+	"campaignku/campaign"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository adalah interface untuk operasi database transaksi.
+type Repository interface {
+	Save(ctx context.Context, transaction Transaction) (Transaction, error)
+	Update(ctx context.Context, transaction Transaction) (Transaction, error)
+	FindByCode(ctx context.Context, code string) (Transaction, error)
+	FindByUserID(ctx context.Context, userID int) ([]Transaction, error)
+	UpdateStatus(ctx context.Context, transaction Transaction, from string) (bool, error)
+	MarkPaid(ctx context.Context, transaction Transaction) (bool, error)
+	MarkRefunded(ctx context.Context, transaction Transaction) (bool, error)
+	QueueRefunds(ctx context.Context, now time.Time) (int64, error)
+	FindDueRefunds(ctx context.Context, now time.Time, limit int) ([]Transaction, error)
+	FindFulfilmentsByCampaignID(ctx context.Context, campaignID int) ([]Transaction, error)
+	UpdateFulfilments(ctx context.Context, transactions []Transaction) error
+	CountBackedCampaigns(ctx context.Context, userID int) (int64, error)
+	FindRecentBackers(ctx context.Context, campaignID int, limit int) ([]Transaction, error)
+	FindStalePending(ctx context.Context, before time.Time) ([]Transaction, error)
+}
+
+// repository adalah implementasi Repository.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository membuat instance Repository dengan koneksi database yang diberikan.
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+// Save menyimpan transaksi baru ke database.
+func (r *repository) Save(ctx context.Context, transaction Transaction) (Transaction, error) {
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Create(&transaction).Error
+	if err != nil {
+		return transaction, err
+	}
+
+	return transaction, nil
+}
+
+// Update memperbarui transaksi di database.
+func (r *repository) Update(ctx context.Context, transaction Transaction) (Transaction, error) {
+	err := r.db.WithContext(ctx).Omit(clause.Associations).Save(&transaction).Error
+	if err != nil {
+		return transaction, err
+	}
+
+	return transaction, nil
+}
+
+// FindByCode mencari transaksi berdasarkan kode uniknya.
+func (r *repository) FindByCode(ctx context.Context, code string) (Transaction, error) {
+	var transaction Transaction
+
+	err := r.db.WithContext(ctx).Where("code = ?", code).Find(&transaction).Error
+	if err != nil {
+		return transaction, err
+	}
+
+	return transaction, nil
+}
+
+// FindByUserID mencari semua transaksi milik user, yang terbaru duluan.
+func (r *repository) FindByUserID(ctx context.Context, userID int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&transactions).Error
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+// UpdateStatus mengubah status transaksi cuma kalo statusnya masih from.
+// Dipake biar notifikasi pembayaran yang dikirim dobel nggak ngitung dana dua kali.
+func (r *repository) UpdateStatus(ctx context.Context, transaction Transaction, from string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Transaction{}).
+		Where("id = ? AND status = ?", transaction.ID, from).
+		Update("status", transaction.Status)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// MarkPaid mengubah transaksi pending jadi paid sekaligus nambahin dana dan jumlah backer campaign-nya.
+// Dua-duanya ada di satu transaksi database, jadi nggak ada transaksi lunas yang dananya nggak kehitung.
+// Balikin false kalo transaksinya udah nggak pending, misal karena notifikasinya dikirim dobel.
+func (r *repository) MarkPaid(ctx context.Context, transaction Transaction) (bool, error) {
+	changed := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Transaction{}).
+			Where("id = ? AND status = ?", transaction.ID, StatusPending).
+			Update("status", StatusPaid)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+
+		changed = true
+		return campaign.NewRepository(tx).AddFunds(ctx, transaction.CampaignID, transaction.Amount, 1)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+// MarkRefunded menyimpan transaksi yang refund-nya berhasil sekaligus ngurangin dana dan jumlah backer campaign-nya.
+// Dua-duanya ada di satu transaksi database, jadi kalo salah satunya gagal refund-nya tetep antri dan dicoba lagi.
+// Balikin false kalo transaksinya udah nggak antri refund.
+func (r *repository) MarkRefunded(ctx context.Context, transaction Transaction) (bool, error) {
+	changed := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Transaction{}).
+			Where("id = ? AND refund_status = ?", transaction.ID, RefundPending).
+			Select("status", "refund_status", "refund_attempts", "refund_error", "next_refund_at", "refunded_at").
+			Updates(transaction)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+
+		changed = true
+		return campaign.NewRepository(tx).AddFunds(ctx, transaction.CampaignID, -transaction.Amount, -1)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+// QueueRefunds menandai transaksi lunas di campaign all-or-nothing yang gagal supaya direfund.
+// Aman dipanggil berulang kali, transaksi yang udah ditandai nggak diubah lagi.
+func (r *repository) QueueRefunds(ctx context.Context, now time.Time) (int64, error) {
+	failedCampaigns := r.db.WithContext(ctx).Model(&campaign.Campaign{}).
+		Select("id").
+		Where("status = ? AND funding_mode = ?", campaign.StatusFailed, campaign.FundingAllOrNothing)
+
+	result := r.db.WithContext(ctx).Model(&Transaction{}).
+		Where("status = ? AND refund_status = ?", StatusPaid, "").
+		Where("campaign_id IN (?)", failedCampaigns).
+		Updates(map[string]interface{}{
+			"refund_status":  RefundPending,
+			"next_refund_at": now,
+		})
+
+	return result.RowsAffected, result.Error
+}
+
+// FindDueRefunds mencari transaksi yang antri refund dan udah waktunya dicoba.
+func (r *repository) FindDueRefunds(ctx context.Context, now time.Time, limit int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	err := r.db.WithContext(ctx).Where("refund_status = ? AND next_refund_at <= ?", RefundPending, now).
+		Order("next_refund_at").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+// FindFulfilmentsByCampaignID mencari transaksi lunas yang milih reward di sebuah campaign, lengkap sama backer dan reward-nya.
+func (r *repository) FindFulfilmentsByCampaignID(ctx context.Context, campaignID int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	err := r.db.WithContext(ctx).Where("campaign_id = ? AND status = ? AND reward_id IS NOT NULL", campaignID, StatusPaid).
+		Preload("User").
+		Preload("Reward").
+		Order("id ASC").
+		Find(&transactions).Error
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+// UpdateFulfilments menyimpan status pengiriman banyak transaksi sekaligus. Kalo satu gagal, semuanya dibatalin.
+func (r *repository) UpdateFulfilments(ctx context.Context, transactions []Transaction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, transaction := range transactions {
+			err := tx.Model(&Transaction{ID: transaction.ID}).
+				Select("fulfilment_status", "tracking_number", "shipped_at", "delivered_at").
+				Updates(transaction).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CountBackedCampaigns menghitung berapa campaign berbeda yang udah didukung (dan dibayar) sama user.
+func (r *repository) CountBackedCampaigns(ctx context.Context, userID int) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Model(&Transaction{}).
+		Where("user_id = ? AND status = ?", userID, StatusPaid).
+		Distinct("campaign_id").
+		Count(&count).Error
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// FindRecentBackers mencari transaksi lunas paling baru di sebuah campaign, lengkap sama data backer-nya.
+func (r *repository) FindRecentBackers(ctx context.Context, campaignID int, limit int) ([]Transaction, error) {
+	var transactions []Transaction
+
+	err := r.db.WithContext(ctx).Where("campaign_id = ? AND status = ?", campaignID, StatusPaid).
+		Preload("User").
+		Order("updated_at DESC, id DESC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
+
+// FindStalePending mencari transaksi yang masih pending dan dibikin sebelum waktu tertentu, yang paling lama duluan.
+func (r *repository) FindStalePending(ctx context.Context, before time.Time) ([]Transaction, error) {
+	var transactions []Transaction
+
+	err := r.db.WithContext(ctx).Where("status = ? AND created_at < ?", StatusPending, before).
+		Order("created_at ASC").
+		Find(&transactions).Error
+	if err != nil {
+		return transactions, err
+	}
+
+	return transactions, nil
+}
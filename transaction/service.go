@@ -0,0 +1,691 @@
+package transaction
+
+import (
+	"campaignku/campaign"
+	"campaignku/mailer"
+	"campaignku/metrics"
+	"campaignku/notification"
+	"campaignku/payment"
+	"campaignku/pubsub"
+	"campaignku/user"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Pengaturan percobaan ulang refund. Jeda antar percobaan dobel tiap kali gagal, mulai dari refundBackoff.
+const (
+	maxRefundAttempts = 8
+	refundBackoff     = time.Minute
+	maxRefundBackoff  = 6 * time.Hour
+	refundBatchSize   = 50
+	refundReason      = "Campaign tidak mencapai target dana"
+	recentBackerLimit = 10
+)
+
+// Jenis event progress pendanaan yang dikirim ke topik campaign.
+const (
+	EventProgressSnapshot = "snapshot" // Data awal waktu pelanggan baru nyambung.
+	EventProgressChanged  = "progress" // Dana atau jumlah backer berubah.
+)
+
+// TopicTransactionPaid adalah topik pubsub yang dikirim tiap ada transaksi yang lunas, atas nama pemilik campaign-nya.
+const TopicTransactionPaid = "transaction.paid"
+
+// Error yang bisa dibalikin sama service transaksi.
+var (
+	ErrTransactionNotFound = errors.New("transaksi tidak ditemukan")
+	ErrInvalidSignature    = errors.New("signature notifikasi tidak valid")
+	ErrAmountBelowReward   = errors.New("jumlah dukungan kurang dari minimal reward")
+	ErrAddressRequired     = errors.New("reward ini perlu dikirim, alamat pengiriman wajib diisi")
+	ErrTrackingRequired    = errors.New("nomor resi wajib diisi untuk reward yang dikirim")
+)
+
+// Service adalah interface yang menentukan operasi-operasi yang dapat dilakukan pada transaksi.
+type Service interface {
+	CreateTransaction(ctx context.Context, input CreateTransactionInput) (Transaction, error)                                        // Fungsi buat bikin transaksi baru dan halaman bayarnya.
+	ProcessPayment(ctx context.Context, input TransactionNotificationInput) error                                                    // Fungsi buat proses notifikasi pembayaran dari Midtrans.
+	GetUserTransactions(ctx context.Context, userID int) ([]Transaction, error)                                                      // Fungsi buat dapetin riwayat transaksi user.
+	RefundFailedCampaigns(ctx context.Context, now time.Time) error                                                                  // Fungsi buat refund dukungan di campaign all-or-nothing yang gagal.
+	GetFulfilments(ctx context.Context, inputID GetCampaignFulfilmentsInput, user user.User) ([]Transaction, error)                  // Fungsi buat dapetin daftar pengiriman reward, khusus pemilik campaign.
+	UpdateFulfilments(ctx context.Context, inputID GetCampaignFulfilmentsInput, input UpdateFulfilmentsInput) ([]Transaction, error) // Fungsi buat ngubah status pengiriman banyak transaksi sekaligus.
+	GetCampaignProgress(ctx context.Context, inputID campaign.GetCampaignDetailInput) (Progress, error)                              // Fungsi buat dapetin progress pendanaan campaign plus backer terbarunya.
+	ReprocessStuckPayments(ctx context.Context, before time.Time) ([]Transaction, error)                                             // Fungsi buat nanya ulang status transaksi pending yang kelamaan ke Midtrans.
+}
+
+// service adalah implementasi dari interface Service.
+type service struct {
+	repository          Repository
+	campaignRepository  campaign.Repository
+	userRepository      user.Repository
+	paymentService      payment.Service
+	notificationService notification.Service // Buat ngabarin pemilik campaign dan backer soal pembayaran.
+	mailService         mailer.Service       // Buat ngirim bukti dukungan dan kabar campaign capai target lewat email.
+	publisher           pubsub.Publisher     // Buat nyebarin perubahan progress pendanaan ke halaman campaign.
+	recorder            metrics.Recorder     // Buat nyatet perubahan status transaksi dan nominalnya.
+}
+
+// NewService digunakan untuk membuat instance baru dari Service.
+func NewService(repository Repository, campaignRepository campaign.Repository, userRepository user.Repository, paymentService payment.Service, notificationService notification.Service, mailService mailer.Service, publisher pubsub.Publisher, recorder metrics.Recorder) *service {
+	return &service{repository, campaignRepository, userRepository, paymentService, notificationService, mailService, publisher, recorder}
+}
+
+// CampaignTopic adalah nama topik pubsub buat event progress pendanaan sebuah campaign.
+func CampaignTopic(campaignID int) string {
+	return fmt.Sprintf("campaign.%d.progress", campaignID)
+}
+
+// CreateTransaction adalah metode untuk bikin transaksi dukungan ke sebuah campaign.
+// Campaign yang belum mulai atau udah ditutup nggak bisa didukung lagi.
+// Kalo backer milih reward, satu stok reward langsung dipesan dan dibalikin lagi kalo transaksinya gagal.
+func (s *service) CreateTransaction(ctx context.Context, input CreateTransactionInput) (Transaction, error) {
+	transaction := Transaction{}
+	transaction.CampaignID = input.CampaignID
+	transaction.UserID = input.User.ID
+	transaction.RewardID = input.RewardID
+	transaction.Amount = input.Amount
+	transaction.Status = StatusPending
+	transaction.Code = fmt.Sprintf("CK-%d-%d", input.User.ID, time.Now().UnixNano())
+
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, input.CampaignID)
+	if err != nil {
+		return transaction, err
+	}
+	if targetCampaign.ID == 0 {
+		return transaction, campaign.ErrCampaignNotFound
+	}
+	if !targetCampaign.IsOpen(time.Now()) {
+		return transaction, campaign.ErrCampaignClosed
+	}
+
+	if input.RewardID != nil {
+		reward, err := s.findReward(ctx, targetCampaign, *input.RewardID, input.Amount)
+		if err != nil {
+			return transaction, err
+		}
+
+		// Reward yang perlu dikirim butuh alamat, alamatnya disalin ke transaksi.
+		if reward.RequiresShipping {
+			shipping, err := s.findShippingAddress(ctx, input)
+			if err != nil {
+				return transaction, err
+			}
+			transaction.Shipping = shipping
+		}
+		transaction.FulfilmentStatus = FulfilmentPending
+
+		reserved, err := s.campaignRepository.ReserveReward(ctx, reward.ID)
+		if err != nil {
+			return transaction, err
+		}
+		if !reserved {
+			return transaction, campaign.ErrRewardSoldOut
+		}
+	}
+
+	newTransaction, err := s.repository.Save(ctx, transaction)
+	if err != nil {
+		s.releaseReward(ctx, transaction)
+		return newTransaction, err
+	}
+	s.recorder.TransactionStatusChanged(newTransaction.Status, newTransaction.Amount)
+
+	// Minta halaman bayar ke payment gateway.
+	paymentTransaction := payment.Transaction{
+		Code:   newTransaction.Code,
+		Amount: newTransaction.Amount,
+	}
+	paymentURL, err := s.paymentService.GetPaymentURL(ctx, paymentTransaction, input.User)
+	if err != nil {
+		// Transaksi yang gagal dibikin halaman bayarnya langsung dibatalin. Pembatalannya tetep dijalanin
+		// walau request-nya udah dibatalin, biar transaksi dan stok reward-nya nggak nyangkut.
+		cleanupCtx := context.WithoutCancel(ctx)
+		newTransaction.Status = StatusCancelled
+		s.repository.Update(cleanupCtx, newTransaction)
+		s.releaseReward(cleanupCtx, newTransaction)
+		s.recorder.TransactionStatusChanged(newTransaction.Status, newTransaction.Amount)
+		return newTransaction, err
+	}
+
+	newTransaction.PaymentURL = paymentURL
+	return s.repository.Update(ctx, newTransaction)
+}
+
+// findReward mastiin reward milik campaign yang didukung dan jumlah dukungannya cukup.
+func (s *service) findReward(ctx context.Context, targetCampaign campaign.Campaign, rewardID int, amount int) (campaign.Reward, error) {
+	reward, err := s.campaignRepository.FindRewardByID(ctx, rewardID)
+	if err != nil {
+		return reward, err
+	}
+	if reward.ID == 0 || reward.CampaignID != targetCampaign.ID {
+		return reward, campaign.ErrRewardNotFound
+	}
+	if amount < reward.MinimumAmount {
+		return reward, ErrAmountBelowReward
+	}
+	return reward, nil
+}
+
+// findShippingAddress ngambil alamat dari buku alamat backer dan nyalinnya jadi alamat pengiriman.
+func (s *service) findShippingAddress(ctx context.Context, input CreateTransactionInput) (ShippingAddress, error) {
+	if input.AddressID == nil {
+		return ShippingAddress{}, ErrAddressRequired
+	}
+
+	address, err := s.userRepository.FindAddressByID(ctx, *input.AddressID)
+	if err != nil {
+		return ShippingAddress{}, err
+	}
+	if address.ID == 0 || address.UserID != input.User.ID {
+		return ShippingAddress{}, user.ErrAddressNotFound
+	}
+
+	shipping := ShippingAddress{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Street:        address.Street,
+		City:          address.City,
+		Province:      address.Province,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
+	}
+	return shipping, nil
+}
+
+// releaseReward ngembaliin stok reward yang dipesan transaksi, kalo ada.
+// Stoknya tetep dikembaliin walau context-nya udah dibatalin, soalnya ini yang bersihin langkah sebelumnya.
+func (s *service) releaseReward(ctx context.Context, transaction Transaction) {
+	if transaction.RewardID == nil {
+		return
+	}
+	if err := s.campaignRepository.ReleaseReward(context.WithoutCancel(ctx), *transaction.RewardID); err != nil {
+		slog.ErrorContext(ctx, "gagal mengembalikan stok reward", "reward_id", *transaction.RewardID, "error", err)
+	}
+}
+
+// ProcessPayment adalah metode untuk proses notifikasi pembayaran dari Midtrans.
+// Transaksi yang lunas nambahin dana dan jumlah backer campaign, cukup sekali walaupun notifikasinya dikirim berkali-kali.
+func (s *service) ProcessPayment(ctx context.Context, input TransactionNotificationInput) error {
+	if !s.paymentService.VerifySignature(input.OrderID, input.StatusCode, input.GrossAmount, input.SignatureKey) {
+		return ErrInvalidSignature
+	}
+
+	transaction, err := s.repository.FindByCode(ctx, input.OrderID)
+	if err != nil {
+		return err
+	}
+	if transaction.ID == 0 {
+		return ErrTransactionNotFound
+	}
+
+	_, _, err = s.applyPaymentStatus(ctx, transaction, input)
+	return err
+}
+
+// applyPaymentStatus nerapin status pembayaran dari Midtrans ke transaksi yang masih pending.
+// Balikin transaksi dengan status barunya, plus true kalo statusnya beneran berubah.
+func (s *service) applyPaymentStatus(ctx context.Context, transaction Transaction, input TransactionNotificationInput) (Transaction, bool, error) {
+	switch {
+	case input.TransactionStatus == "settlement",
+		input.TransactionStatus == "capture" && input.FraudStatus == "accept":
+		transaction.Status = StatusPaid
+	case input.TransactionStatus == "deny",
+		input.TransactionStatus == "expire",
+		input.TransactionStatus == "cancel":
+		transaction.Status = StatusCancelled
+	default:
+		// Status lain (misal masih pending) nggak ngubah apa-apa.
+		return transaction, false, nil
+	}
+
+	// Transaksi yang lunas langsung nambahin dana campaign bareng perubahan statusnya.
+	var changed bool
+	var err error
+	if transaction.Status == StatusPaid {
+		changed, err = s.repository.MarkPaid(ctx, transaction)
+	} else {
+		changed, err = s.repository.UpdateStatus(ctx, transaction, StatusPending)
+	}
+	if err != nil {
+		return transaction, false, err
+	}
+	if !changed {
+		return transaction, false, nil
+	}
+	s.recorder.TransactionStatusChanged(transaction.Status, transaction.Amount)
+
+	// Statusnya udah kecatat, jadi kabar-kabarnya tetep dikirim walau Midtrans keburu nutup koneksinya.
+	ctx = context.WithoutCancel(ctx)
+
+	// Pembayaran yang kadaluarsa atau batal ngembaliin stok reward-nya.
+	if transaction.Status == StatusCancelled {
+		s.releaseReward(ctx, transaction)
+		s.notifyPaymentFailed(ctx, transaction)
+		return transaction, true, nil
+	}
+
+	s.announcePayment(ctx, transaction)
+	return transaction, true, nil
+}
+
+// ReprocessStuckPayments nanya ulang ke Midtrans status transaksi yang masih pending sejak sebelum waktu tertentu,
+// misal karena notifikasinya nggak pernah nyampe. Transaksi yang nggak dikenal Midtrans dianggap kadaluarsa.
+// Transaksi yang gagal diproses dicatat dan dilewatin biar sisanya tetep jalan, error-nya digabung dan dibalikin di akhir.
+// Balikin transaksi yang statusnya berubah.
+func (s *service) ReprocessStuckPayments(ctx context.Context, before time.Time) ([]Transaction, error) {
+	transactions, err := s.repository.FindStalePending(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := []Transaction{}
+	var errs []error
+	for _, transaction := range transactions {
+		updated, err := s.reprocessPayment(ctx, transaction)
+		if err != nil {
+			slog.ErrorContext(ctx, "gagal memproses ulang transaksi", "transaction_id", transaction.ID, "code", transaction.Code, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if updated.ID != 0 {
+			changed = append(changed, updated)
+		}
+	}
+
+	return changed, errors.Join(errs...)
+}
+
+// reprocessPayment nanya status satu transaksi ke Midtrans lalu nerapin hasilnya.
+// Balikin transaksi dengan status barunya, atau Transaction kosong kalo statusnya nggak berubah.
+func (s *service) reprocessPayment(ctx context.Context, transaction Transaction) (Transaction, error) {
+	status, err := s.paymentService.GetStatus(ctx, transaction.Code)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("gagal mengambil status transaksi %s: %w", transaction.Code, err)
+	}
+
+	input := TransactionNotificationInput{
+		TransactionStatus: status.TransactionStatus,
+		OrderID:           transaction.Code,
+		PaymentType:       status.PaymentType,
+		FraudStatus:       status.FraudStatus,
+		StatusCode:        status.StatusCode,
+		GrossAmount:       status.GrossAmount,
+	}
+
+	switch {
+	case status.StatusCode == "404":
+		input.TransactionStatus = "expire"
+	case !s.paymentService.VerifySignature(transaction.Code, status.StatusCode, status.GrossAmount, status.SignatureKey):
+		return Transaction{}, fmt.Errorf("status transaksi %s: %w", transaction.Code, ErrInvalidSignature)
+	}
+
+	transaction, updated, err := s.applyPaymentStatus(ctx, transaction, input)
+	if err != nil || !updated {
+		return Transaction{}, err
+	}
+	return transaction, nil
+}
+
+// GetUserTransactions adalah metode untuk dapetin riwayat transaksi milik user.
+func (s *service) GetUserTransactions(ctx context.Context, userID int) ([]Transaction, error) {
+	return s.repository.FindByUserID(ctx, userID)
+}
+
+// RefundFailedCampaigns adalah metode untuk ngembaliin dana semua transaksi lunas di campaign all-or-nothing yang gagal.
+// Transaksi yang gagal direfund dicoba lagi nanti dengan jeda yang makin lama, sampai maxRefundAttempts.
+func (s *service) RefundFailedCampaigns(ctx context.Context, now time.Time) error {
+	_, err := s.repository.QueueRefunds(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := s.repository.FindDueRefunds(ctx, now, refundBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		if err := s.refund(ctx, transaction, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refund nyoba ngerefund satu transaksi dan nyimpen hasilnya.
+// Error dari payment gateway nggak dibalikin, cukup dicatat di transaksinya biar dicoba lagi.
+func (s *service) refund(ctx context.Context, transaction Transaction, now time.Time) error {
+	paymentTransaction := payment.Transaction{
+		Code:   transaction.Code,
+		Amount: transaction.Amount,
+	}
+
+	transaction.RefundAttempts++
+
+	err := s.paymentService.Refund(ctx, paymentTransaction, refundReason)
+	if err != nil {
+		transaction.RefundError = err.Error()
+		if transaction.RefundAttempts >= maxRefundAttempts {
+			transaction.RefundStatus = RefundFailed
+			transaction.NextRefundAt = nil
+		} else {
+			nextRefundAt := now.Add(refundDelay(transaction.RefundAttempts))
+			transaction.NextRefundAt = &nextRefundAt
+		}
+
+		_, err = s.repository.Update(ctx, transaction)
+		return err
+	}
+
+	refundedAt := now
+	transaction.Status = StatusRefunded
+	transaction.RefundStatus = RefundSucceeded
+	transaction.RefundError = ""
+	transaction.NextRefundAt = nil
+	transaction.RefundedAt = &refundedAt
+
+	// Dana dan jumlah backer campaign dikurangin lagi sesuai transaksi yang direfund, bareng sama status refund-nya.
+	changed, err := s.repository.MarkRefunded(ctx, transaction)
+	if err != nil || !changed {
+		return err
+	}
+	s.recorder.TransactionStatusChanged(transaction.Status, transaction.Amount)
+
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, transaction.CampaignID)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil campaign", "campaign_id", transaction.CampaignID, "error", err)
+		return nil
+	}
+
+	s.publishProgress(ctx, targetCampaign, nil)
+	return nil
+}
+
+// refundDelay ngitung jeda sebelum percobaan refund berikutnya.
+func refundDelay(attempts int) time.Duration {
+	delay := refundBackoff << (attempts - 1)
+	if delay > maxRefundBackoff || delay <= 0 {
+		return maxRefundBackoff
+	}
+	return delay
+}
+
+// GetFulfilments adalah metode untuk dapetin semua transaksi lunas yang milih reward di sebuah campaign.
+// Cuma pemilik campaign yang boleh liat, karena isinya alamat pengiriman backer.
+func (s *service) GetFulfilments(ctx context.Context, inputID GetCampaignFulfilmentsInput, user user.User) ([]Transaction, error) {
+	if err := s.checkCampaignOwner(ctx, inputID.ID, user); err != nil {
+		return nil, err
+	}
+
+	return s.repository.FindFulfilmentsByCampaignID(ctx, inputID.ID)
+}
+
+// UpdateFulfilments adalah metode untuk ngubah status pengiriman banyak transaksi sekaligus.
+// Semua item dicek dulu, jadi kalo ada satu yang nggak valid nggak ada yang diubah.
+func (s *service) UpdateFulfilments(ctx context.Context, inputID GetCampaignFulfilmentsInput, input UpdateFulfilmentsInput) ([]Transaction, error) {
+	fulfilments, err := s.GetFulfilments(ctx, inputID, input.User)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]Transaction, len(fulfilments))
+	for _, fulfilment := range fulfilments {
+		byID[fulfilment.ID] = fulfilment
+	}
+
+	now := time.Now()
+	updated := make([]Transaction, 0, len(input.Items))
+	for _, item := range input.Items {
+		transaction, ok := byID[item.TransactionID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrTransactionNotFound, item.TransactionID)
+		}
+
+		requiresShipping := transaction.Reward != nil && transaction.Reward.RequiresShipping
+		if item.Status == FulfilmentShipped && requiresShipping && item.TrackingNumber == "" {
+			return nil, fmt.Errorf("%w: %d", ErrTrackingRequired, item.TransactionID)
+		}
+
+		transaction.FulfilmentStatus = item.Status
+		if item.TrackingNumber != "" {
+			transaction.TrackingNumber = item.TrackingNumber
+		}
+
+		switch item.Status {
+		case FulfilmentPending:
+			transaction.ShippedAt = nil
+			transaction.DeliveredAt = nil
+		case FulfilmentShipped:
+			if transaction.ShippedAt == nil {
+				transaction.ShippedAt = &now
+			}
+			transaction.DeliveredAt = nil
+		case FulfilmentDelivered:
+			if transaction.ShippedAt == nil {
+				transaction.ShippedAt = &now
+			}
+			if transaction.DeliveredAt == nil {
+				transaction.DeliveredAt = &now
+			}
+		}
+
+		byID[transaction.ID] = transaction
+		updated = append(updated, transaction)
+	}
+
+	if err := s.repository.UpdateFulfilments(ctx, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// checkCampaignOwner mastiin campaign-nya ada dan user-nya pemilik campaign itu.
+func (s *service) checkCampaignOwner(ctx context.Context, campaignID int, user user.User) error {
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if targetCampaign.ID == 0 {
+		return campaign.ErrCampaignNotFound
+	}
+	if targetCampaign.UserId != user.ID {
+		return campaign.ErrNotOwner
+	}
+	return nil
+}
+
+// GetCampaignProgress adalah metode untuk dapetin progress pendanaan campaign plus beberapa backer terbarunya.
+func (s *service) GetCampaignProgress(ctx context.Context, inputID campaign.GetCampaignDetailInput) (Progress, error) {
+	progress := Progress{}
+
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, inputID.ID)
+	if err != nil {
+		return progress, err
+	}
+	if targetCampaign.ID == 0 {
+		return progress, campaign.ErrCampaignNotFound
+	}
+	progress.Campaign = targetCampaign
+
+	recentBackers, err := s.repository.FindRecentBackers(ctx, targetCampaign.ID, recentBackerLimit)
+	if err != nil {
+		return progress, err
+	}
+	progress.RecentBackers = recentBackers
+
+	return progress, nil
+}
+
+// announcePayment nyebarin kabar pembayaran yang baru lunas: progress pendanaan ke halaman campaign,
+// event transaction.paid ke pemilik campaign, dan event campaign.funded kalo pembayaran ini bikin campaign-nya capai target.
+// Kalo gagal cuma dicatat aja, pembayarannya tetep udah diproses.
+func (s *service) announcePayment(ctx context.Context, transaction Transaction) {
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, transaction.CampaignID)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil campaign", "campaign_id", transaction.CampaignID, "error", err)
+		return
+	}
+
+	backer, err := s.userRepository.FindByID(ctx, transaction.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil backer transaksi", "transaction_id", transaction.ID, "error", err)
+		return
+	}
+	transaction.User = backer
+	transaction.Campaign = targetCampaign
+	transaction.UpdatedAt = time.Now()
+
+	s.publishProgress(ctx, targetCampaign, &transaction)
+	s.publish(ctx, TopicTransactionPaid, targetCampaign.UserId, FormatPaidEvent(transaction))
+
+	s.sendReceipt(ctx, transaction)
+	s.notify(ctx, []int{targetCampaign.UserId}, notification.Notification{
+		Type:  notification.TypeNewBacker,
+		Title: fmt.Sprintf("Dukungan baru untuk %s", targetCampaign.Name),
+		Body:  fmt.Sprintf("%s mendukung sebesar Rp%d", backer.Name, transaction.Amount),
+		Link:  fmt.Sprintf("/campaigns/%d", targetCampaign.ID),
+	})
+
+	fundedBefore := targetCampaign.CurrentAmount-transaction.Amount >= targetCampaign.GoalAmount
+	if !fundedBefore && targetCampaign.CurrentAmount >= targetCampaign.GoalAmount {
+		s.publish(ctx, campaign.TopicCampaignFunded, targetCampaign.UserId, campaign.FormatCampaign(targetCampaign))
+		s.notifyFunded(ctx, targetCampaign)
+	}
+}
+
+// notifyFunded ngabarin pemilik campaign dan semua backer-nya kalo campaign-nya udah capai target.
+func (s *service) notifyFunded(ctx context.Context, targetCampaign campaign.Campaign) {
+	backerIDs, err := s.campaignRepository.FindBackerIDs(ctx, targetCampaign.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil backer campaign", "campaign_id", targetCampaign.ID, "error", err)
+		return
+	}
+
+	recipients := []int{targetCampaign.UserId}
+	for _, backerID := range backerIDs {
+		if backerID != targetCampaign.UserId {
+			recipients = append(recipients, backerID)
+		}
+	}
+
+	s.notify(ctx, recipients, notification.Notification{
+		Type:  notification.TypeCampaignFunded,
+		Title: fmt.Sprintf("%s mencapai target", targetCampaign.Name),
+		Body:  fmt.Sprintf("Campaign ini sudah mengumpulkan Rp%d dari target Rp%d", targetCampaign.CurrentAmount, targetCampaign.GoalAmount),
+		Link:  fmt.Sprintf("/campaigns/%d", targetCampaign.ID),
+	})
+	s.mailFunded(ctx, targetCampaign, recipients)
+}
+
+// sendReceipt ngirim bukti dukungan ke email backer. Transaksinya harus udah lengkap sama campaign dan backer-nya.
+func (s *service) sendReceipt(ctx context.Context, transaction Transaction) {
+	data := mailer.BackingReceiptData{
+		Name:         transaction.User.Name,
+		CampaignName: transaction.Campaign.Name,
+		CampaignPath: fmt.Sprintf("/campaigns/%d", transaction.CampaignID),
+		Code:         transaction.Code,
+		Amount:       transaction.Amount,
+	}
+
+	if transaction.RewardID != nil {
+		reward, err := s.campaignRepository.FindRewardByID(ctx, *transaction.RewardID)
+		if err != nil {
+			slog.ErrorContext(ctx, "gagal mengambil reward transaksi", "transaction_id", transaction.ID, "error", err)
+		}
+		data.RewardTitle = reward.Title
+	}
+
+	s.sendMail(ctx, transaction.User, mailer.TemplateBackingReceipt, data)
+}
+
+// mailFunded ngirim email campaign capai target ke penerima yang nggak matiin email buat jenis notifikasi ini.
+func (s *service) mailFunded(ctx context.Context, targetCampaign campaign.Campaign, recipientIDs []int) {
+	recipientIDs, err := s.notificationService.EmailRecipients(ctx, recipientIDs, notification.TypeCampaignFunded)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil preferensi email campaign", "campaign_id", targetCampaign.ID, "error", err)
+		return
+	}
+
+	recipients, err := s.userRepository.FindByIDs(ctx, recipientIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil penerima email campaign", "campaign_id", targetCampaign.ID, "error", err)
+		return
+	}
+
+	for _, recipient := range recipients {
+		s.sendMail(ctx, recipient, mailer.TemplateCampaignFunded, mailer.CampaignFundedData{
+			Name:          recipient.Name,
+			CampaignName:  targetCampaign.Name,
+			CampaignPath:  fmt.Sprintf("/campaigns/%d", targetCampaign.ID),
+			CurrentAmount: targetCampaign.CurrentAmount,
+			GoalAmount:    targetCampaign.GoalAmount,
+			IsOwner:       recipient.ID == targetCampaign.UserId,
+		})
+	}
+}
+
+// sendMail masukin email buat pengguna ke antrean. Kalo gagal cuma dicatat aja.
+func (s *service) sendMail(ctx context.Context, recipient user.User, template string, data interface{}) {
+	to := mailer.Recipient{Email: recipient.Email, Name: recipient.Name, Locale: recipient.Locale}
+	if err := s.mailService.Send(ctx, to, template, data); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim email", "template", template, "user_id", recipient.ID, "error", err)
+	}
+}
+
+// notifyPaymentFailed ngabarin backer kalo pembayarannya gagal atau kadaluarsa.
+func (s *service) notifyPaymentFailed(ctx context.Context, transaction Transaction) {
+	targetCampaign, err := s.campaignRepository.FindByID(ctx, transaction.CampaignID)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal mengambil campaign", "campaign_id", transaction.CampaignID, "error", err)
+		return
+	}
+
+	s.notify(ctx, []int{transaction.UserID}, notification.Notification{
+		Type:  notification.TypePaymentFailed,
+		Title: "Pembayaran gagal",
+		Body:  fmt.Sprintf("Pembayaran Rp%d untuk %s gagal atau sudah kadaluarsa", transaction.Amount, targetCampaign.Name),
+		Link:  fmt.Sprintf("/campaigns/%d", targetCampaign.ID),
+	})
+}
+
+// notify nyimpen notifikasi buat para penerimanya. Kalo gagal cuma dicatat aja.
+func (s *service) notify(ctx context.Context, userIDs []int, message notification.Notification) {
+	if err := s.notificationService.Notify(ctx, userIDs, message); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim notifikasi", "type", message.Type, "error", err)
+	}
+}
+
+// publishProgress ngirim progress pendanaan terbaru ke topik campaign. Backer boleh nil kalo perubahannya bukan karena dukungan baru.
+func (s *service) publishProgress(ctx context.Context, targetCampaign campaign.Campaign, backer *Transaction) {
+	progress := Progress{Campaign: targetCampaign, Backer: backer}
+
+	payload, err := json.Marshal(FormatProgress(progress))
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal menyusun progress campaign", "campaign_id", targetCampaign.ID, "error", err)
+		return
+	}
+
+	event := pubsub.Event{Type: EventProgressChanged, Payload: payload}
+	if err := s.publisher.Publish(ctx, CampaignTopic(targetCampaign.ID), event); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim progress campaign", "campaign_id", targetCampaign.ID, "error", err)
+	}
+}
+
+// publish ngirim event ke sebuah topik atas nama pemiliknya. Kalo gagal cuma dicatat aja.
+func (s *service) publish(ctx context.Context, topic string, userID int, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		slog.ErrorContext(ctx, "gagal menyusun event", "topic", topic, "error", err)
+		return
+	}
+
+	event := pubsub.Event{Type: topic, UserID: userID, Payload: payload}
+	if err := s.publisher.Publish(ctx, topic, event); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim event", "topic", topic, "error", err)
+	}
+}
@@ -2,15 +2,54 @@ package user
 
 import "time"
 
+// Role yang bisa dimiliki pengguna.
+const (
+	RoleUser  = "user"  // Pengguna biasa.
+	RoleAdmin = "admin" // Admin, bisa akses endpoint yang dijaga adminMiddleware.
+)
+
+// Roles adalah daftar semua role yang dikenal.
+var Roles = []string{RoleUser, RoleAdmin}
+
 // User adalah struktur data yang merepresentasikan entitas pengguna (user).
 type User struct {
 	ID             int
 	Name           string
 	Occupation     string
+	Bio            string
 	Email          string
 	PasswordHash   string
 	AvatarFileName string
 	Role           string
+	Locale         string // Bahasa email buat pengguna ini, kosong artinya bahasa Indonesia.
+	FollowerCount  int
 	CreateAt       time.Time `gorm:"column:created_at"`
 	UpdateAt       time.Time `gorm:"column:updated_at"`
 }
+
+// PasswordReset adalah permintaan ganti password. Yang disimpen cuma hash token-nya, token aslinya cuma ada di email.
+type PasswordReset struct {
+	ID        int
+	UserID    int
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// Address adalah alamat pengiriman di buku alamat pengguna.
+type Address struct {
+	ID            int
+	UserID        int
+	Label         string
+	RecipientName string
+	Phone         string
+	Street        string
+	City          string
+	Province      string
+	PostalCode    string
+	Country       string
+	IsDefault     bool
+	CreateAt      time.Time `gorm:"column:created_at"`
+	UpdateAt      time.Time `gorm:"column:updated_at"`
+}
@@ -23,3 +23,46 @@ func FormatUser(user User, token string) UserFormatter {
 	// Mengembalikan instance UserFormatter yang telah diformat.
 	return formatter // Kembalikan data pengguna yang telah diformat.
 }
+
+// AddressFormatter adalah struktur data yang digunakan untuk memformat alamat sebelum dikirim sebagai respons API.
+type AddressFormatter struct {
+	ID            int    `json:"id"`
+	Label         string `json:"label"`
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+	Country       string `json:"country"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+// FormatAddress adalah fungsi yang menghasilkan instance AddressFormatter berdasarkan instance Address.
+func FormatAddress(address Address) AddressFormatter {
+	formatter := AddressFormatter{
+		ID:            address.ID,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Street:        address.Street,
+		City:          address.City,
+		Province:      address.Province,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
+		IsDefault:     address.IsDefault,
+	}
+
+	return formatter
+}
+
+// FormatAddresses adalah fungsi yang menghasilkan daftar AddressFormatter berdasarkan daftar Address.
+func FormatAddresses(addresses []Address) []AddressFormatter {
+	addressesFormatter := []AddressFormatter{}
+
+	for _, address := range addresses {
+		addressesFormatter = append(addressesFormatter, FormatAddress(address))
+	}
+
+	return addressesFormatter
+}
@@ -6,6 +6,7 @@ type RegisterUserInput struct {
 	Occupation string `json:"occupation" binding:"required"`
 	Email      string `json:"email" binding:"required,email"`
 	Password   string `json:"password" binding:"required"`
+	Locale     string `json:"locale" binding:"omitempty,oneof=id en"` // Bahasa email, kosong artinya bahasa Indonesia.
 }
 
 // LoginInput adalah struktur data yang digunakan sebagai input saat pengguna melakukan login.
@@ -18,3 +19,42 @@ type LoginInput struct {
 type CheckEmailInput struct {
 	Email string `json:"email" binding:"required,email"`
 }
+
+// UpdateProfileInput adalah struktur data yang digunakan sebagai input saat pengguna mengubah profil publiknya.
+type UpdateProfileInput struct {
+	Name       string `json:"name" binding:"required"`
+	Occupation string `json:"occupation" binding:"required"`
+	Bio        string `json:"bio" binding:"max=500"`
+	Locale     string `json:"locale" binding:"omitempty,oneof=id en"` // Kosong artinya bahasa email nggak diubah.
+	User       User   // Diisi dari user yang lagi login, bukan dari JSON.
+}
+
+// RequestPasswordResetInput adalah struktur data yang digunakan sebagai input saat pengguna lupa password.
+type RequestPasswordResetInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordInput adalah struktur data yang digunakan sebagai input saat pengguna bikin password baru dari tautan di email.
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// GetAddressInput adalah struktur data buat nampung ID alamat dari URI.
+type GetAddressInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// AddressInput adalah struktur data yang digunakan sebagai input saat menambah atau mengubah alamat.
+type AddressInput struct {
+	Label         string `json:"label" binding:"max=50"`
+	RecipientName string `json:"recipient_name" binding:"required,max=100"`
+	Phone         string `json:"phone" binding:"required,max=20"`
+	Street        string `json:"street" binding:"required"`
+	City          string `json:"city" binding:"required,max=100"`
+	Province      string `json:"province" binding:"required,max=100"`
+	PostalCode    string `json:"postal_code" binding:"required,max=10"`
+	Country       string `json:"country" binding:"omitempty,max=100"`
+	IsDefault     bool   `json:"is_default"`
+	User          User   // Diisi dari user yang lagi login, bukan dari JSON.
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,10 +9,19 @@ import (
 
 // Repository adalah interface untuk operasi database pengguna.
 type Repository interface {
-	Save(user User) (User, error)
-	FindByEmail(email string) (User, error)
-	FindByID(ID int) (User, error)
-	Update(user User) (User, error)
+	Save(ctx context.Context, user User) (User, error)
+	FindByEmail(ctx context.Context, email string) (User, error)
+	FindByID(ctx context.Context, ID int) (User, error)
+	FindByIDs(ctx context.Context, IDs []int) ([]User, error)
+	Update(ctx context.Context, user User) (User, error)
+	FindAddressesByUserID(ctx context.Context, userID int) ([]Address, error)
+	FindAddressByID(ctx context.Context, ID int) (Address, error)
+	SaveAddress(ctx context.Context, address Address) (Address, error)
+	UpdateAddress(ctx context.Context, address Address) (Address, error)
+	DeleteAddress(ctx context.Context, address Address) error
+	SavePasswordReset(ctx context.Context, reset PasswordReset) (PasswordReset, error)
+	FindPasswordResetByTokenHash(ctx context.Context, tokenHash string) (PasswordReset, error)
+	ResetPassword(ctx context.Context, reset PasswordReset, passwordHash string, now time.Time) (bool, error)
 }
 
 // repository adalah implementasi Repository.
@@ -25,12 +35,12 @@ func NewRepository(db *gorm.DB) *repository {
 }
 
 // Save menyimpan pengguna ke database.
-func (r *repository) Save(user User) (User, error) {
+func (r *repository) Save(ctx context.Context, user User) (User, error) {
 	now := time.Now()
 	user.CreateAt = now
 	user.UpdateAt = now
 
-	err := r.db.Create(&user).Error
+	err := r.db.WithContext(ctx).Create(&user).Error
 	if err != nil {
 		return user, err
 	}
@@ -39,36 +49,179 @@ func (r *repository) Save(user User) (User, error) {
 }
 
 // FindByEmail mencari pengguna berdasarkan alamat email.
-func (r *repository) FindByEmail(email string) (User, error) {
+func (r *repository) FindByEmail(ctx context.Context, email string) (User, error) {
 	var user User
 
-	err := r.db.Where("email = ?", email).Find(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).Find(&user).Error
 	if err != nil {
-		return user, nil
+		return user, err
 	}
 
 	return user, nil
 }
 
 // FindByID mencari pengguna berdasarkan ID.
-func (r *repository) FindByID(ID int) (User, error) {
+func (r *repository) FindByID(ctx context.Context, ID int) (User, error) {
 	var user User
 
-	err := r.db.Where("ID = ?", ID).Find(&user).Error
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Find(&user).Error
 	if err != nil {
-		return user, nil
+		return user, err
 	}
 
 	return user, nil
 }
 
+// FindByIDs mencari banyak pengguna sekaligus berdasarkan ID-nya.
+func (r *repository) FindByIDs(ctx context.Context, IDs []int) ([]User, error) {
+	var users []User
+	if len(IDs) == 0 {
+		return users, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("id IN ?", IDs).Find(&users).Error
+	if err != nil {
+		return users, err
+	}
+
+	return users, nil
+}
+
 // Update memperbarui informasi pengguna di database.
-func (r *repository) Update(user User) (User, error) {
-	err := r.db.Save(&user).Error
+func (r *repository) Update(ctx context.Context, user User) (User, error) {
+	// Jumlah pengikut cuma boleh diubah secara atomik waktu follow dan unfollow.
+	err := r.db.WithContext(ctx).Omit("follower_count").Save(&user).Error
 
 	if err != nil {
-		return user, nil
+		return user, err
 	}
 
 	return user, nil
 }
+
+// FindAddressesByUserID mencari semua alamat milik pengguna, alamat utama duluan.
+func (r *repository) FindAddressesByUserID(ctx context.Context, userID int) ([]Address, error) {
+	var addresses []Address
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("is_default DESC, id ASC").Find(&addresses).Error
+	if err != nil {
+		return addresses, err
+	}
+
+	return addresses, nil
+}
+
+// FindAddressByID mencari alamat berdasarkan ID.
+func (r *repository) FindAddressByID(ctx context.Context, ID int) (Address, error) {
+	var address Address
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Find(&address).Error
+	if err != nil {
+		return address, err
+	}
+
+	return address, nil
+}
+
+// SaveAddress menyimpan alamat baru ke database.
+// Kalo alamat baru dijadiin alamat utama, alamat utama yang lama dilepas dulu.
+func (r *repository) SaveAddress(ctx context.Context, address Address) (Address, error) {
+	now := time.Now()
+	address.CreateAt = now
+	address.UpdateAt = now
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if address.IsDefault {
+			if err := clearDefaultAddress(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Create(&address).Error
+	})
+	if err != nil {
+		return address, err
+	}
+
+	return address, nil
+}
+
+// UpdateAddress memperbarui alamat di database, sama kayak SaveAddress soal alamat utama.
+func (r *repository) UpdateAddress(ctx context.Context, address Address) (Address, error) {
+	address.UpdateAt = time.Now()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if address.IsDefault {
+			if err := clearDefaultAddress(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Save(&address).Error
+	})
+	if err != nil {
+		return address, err
+	}
+
+	return address, nil
+}
+
+// DeleteAddress menghapus alamat dari database.
+func (r *repository) DeleteAddress(ctx context.Context, address Address) error {
+	return r.db.WithContext(ctx).Delete(&address).Error
+}
+
+// clearDefaultAddress ngelepas tanda alamat utama dari semua alamat milik pengguna.
+func clearDefaultAddress(tx *gorm.DB, userID int) error {
+	return tx.Model(&Address{}).Where("user_id = ? AND is_default = ?", userID, true).Update("is_default", false).Error
+}
+
+// SavePasswordReset menyimpan permintaan ganti password baru.
+func (r *repository) SavePasswordReset(ctx context.Context, reset PasswordReset) (PasswordReset, error) {
+	reset.CreatedAt = time.Now()
+
+	err := r.db.WithContext(ctx).Create(&reset).Error
+	if err != nil {
+		return reset, err
+	}
+
+	return reset, nil
+}
+
+// FindPasswordResetByTokenHash mencari permintaan ganti password berdasarkan hash token-nya.
+func (r *repository) FindPasswordResetByTokenHash(ctx context.Context, tokenHash string) (PasswordReset, error) {
+	var reset PasswordReset
+
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).Find(&reset).Error
+	if err != nil {
+		return reset, err
+	}
+
+	return reset, nil
+}
+
+// ResetPassword nandain permintaan ganti password udah dipake sekaligus nyimpen password barunya.
+// Balikin false kalo permintaannya ternyata udah dipake duluan, misal tautannya dibuka dua kali barengan.
+func (r *repository) ResetPassword(ctx context.Context, reset PasswordReset, passwordHash string, now time.Time) (bool, error) {
+	used := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&PasswordReset{}).
+			Where("id = ? AND used_at IS NULL", reset.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		used = true
+		return tx.Model(&User{}).
+			Where("id = ?", reset.UserID).
+			Updates(map[string]interface{}{"password_hash": passwordHash, "updated_at": now}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return used, nil
+}
@@ -0,0 +1,287 @@
+package user
+
+import (
+	"campaignku/config"
+	"campaignku/migration"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB bikin database SQLite di memori yang udah dimigrasi, biar query repository dites ke SQL beneran.
+// Koneksinya dibatesin satu, soalnya tiap koneksi ":memory:" punya database sendiri-sendiri.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	cfg := config.DatabaseConfig{Driver: config.DriverSQLite, Path: ":memory:"}
+	db, err := gorm.Open(sqlite.Open(cfg.DSN()), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gagal membuka database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("gagal mengambil koneksi database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("gagal menyiapkan migrasi: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("gagal menerapkan migrasi: %v", err)
+	}
+
+	return db
+}
+
+// saveTestUser nyimpen satu pengguna dan gagalin tes kalo nggak bisa.
+func saveTestUser(t *testing.T, r *repository, name string, email string) User {
+	t.Helper()
+
+	user, err := r.Save(context.Background(), User{Name: name, Email: email, PasswordHash: "hash", Role: RoleUser})
+	if err != nil {
+		t.Fatalf("gagal menyimpan pengguna %s: %v", email, err)
+	}
+	return user
+}
+
+func TestRepositorySaveAndFind(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepository(newTestDB(t))
+
+	saved := saveTestUser(t, r, "Budi", "budi@example.com")
+	if saved.ID == 0 {
+		t.Fatal("ID pengguna yang disimpan masih 0")
+	}
+
+	byID, err := r.FindByID(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if byID.Email != "budi@example.com" || byID.Name != "Budi" || byID.Role != RoleUser {
+		t.Errorf("FindByID = %+v, mau pengguna Budi", byID)
+	}
+
+	byEmail, err := r.FindByEmail(ctx, "budi@example.com")
+	if err != nil {
+		t.Fatalf("FindByEmail: %v", err)
+	}
+	if byEmail.ID != saved.ID {
+		t.Errorf("FindByEmail dapet ID %d, mau %d", byEmail.ID, saved.ID)
+	}
+}
+
+func TestRepositorySaveDuplicateEmail(t *testing.T) {
+	r := NewRepository(newTestDB(t))
+
+	saveTestUser(t, r, "Budi", "budi@example.com")
+
+	_, err := r.Save(context.Background(), User{Name: "Budi Lain", Email: "budi@example.com"})
+	if err == nil {
+		t.Fatal("Save dengan email yang udah dipake harusnya gagal")
+	}
+}
+
+func TestRepositoryUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	r := NewRepository(db)
+
+	saved := saveTestUser(t, r, "Budi", "budi@example.com")
+
+	// Jumlah pengikut diubah langsung kayak waktu di-follow, Update nggak boleh nimpa angkanya.
+	if err := db.Model(&User{}).Where("id = ?", saved.ID).Update("follower_count", 5).Error; err != nil {
+		t.Fatalf("gagal mengubah follower_count: %v", err)
+	}
+
+	saved.Name = "Budi Santoso"
+	saved.Bio = "Suka bikin campaign"
+	saved.Locale = "en"
+	if _, err := r.Update(ctx, saved); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	updated, err := r.FindByID(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if updated.Name != "Budi Santoso" || updated.Bio != "Suka bikin campaign" || updated.Locale != "en" {
+		t.Errorf("perubahan nggak kesimpen: %+v", updated)
+	}
+	if updated.FollowerCount != 5 {
+		t.Errorf("FollowerCount = %d, mau tetep 5", updated.FollowerCount)
+	}
+}
+
+func TestRepositoryFindByIDs(t *testing.T) {
+	r := NewRepository(newTestDB(t))
+
+	first := saveTestUser(t, r, "Budi", "budi@example.com")
+	saveTestUser(t, r, "Siti", "siti@example.com")
+	third := saveTestUser(t, r, "Maya", "maya@example.com")
+
+	users, err := r.FindByIDs(context.Background(), []int{first.ID, third.ID, third.ID + 100})
+	if err != nil {
+		t.Fatalf("FindByIDs: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("FindByIDs dapet %d pengguna, mau 2", len(users))
+	}
+	for _, user := range users {
+		if user.ID != first.ID && user.ID != third.ID {
+			t.Errorf("FindByIDs balikin pengguna %d yang nggak diminta", user.ID)
+		}
+	}
+}
+
+func TestRepositoryDefaultAddress(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepository(newTestDB(t))
+
+	owner := saveTestUser(t, r, "Budi", "budi@example.com")
+
+	home, err := r.SaveAddress(ctx, Address{UserID: owner.ID, Label: "Rumah", Street: "Jl. Mawar 1", IsDefault: true})
+	if err != nil {
+		t.Fatalf("SaveAddress: %v", err)
+	}
+	office, err := r.SaveAddress(ctx, Address{UserID: owner.ID, Label: "Kantor", Street: "Jl. Melati 2", IsDefault: true})
+	if err != nil {
+		t.Fatalf("SaveAddress: %v", err)
+	}
+
+	// Alamat utama cuma boleh satu, dan yang utama tampil paling atas.
+	addresses, err := r.FindAddressesByUserID(ctx, owner.ID)
+	if err != nil {
+		t.Fatalf("FindAddressesByUserID: %v", err)
+	}
+	if len(addresses) != 2 {
+		t.Fatalf("FindAddressesByUserID dapet %d alamat, mau 2", len(addresses))
+	}
+	if addresses[0].ID != office.ID || !addresses[0].IsDefault || addresses[1].ID != home.ID || addresses[1].IsDefault {
+		t.Errorf("urutan atau alamat utama salah: %+v", addresses)
+	}
+}
+
+func TestRepositoryResetPassword(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepository(newTestDB(t))
+
+	owner := saveTestUser(t, r, "Budi", "budi@example.com")
+
+	reset, err := r.SavePasswordReset(ctx, PasswordReset{UserID: owner.ID, TokenHash: "abc", ExpiresAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("SavePasswordReset: %v", err)
+	}
+
+	found, err := r.FindPasswordResetByTokenHash(ctx, "abc")
+	if err != nil || found.ID != reset.ID {
+		t.Fatalf("FindPasswordResetByTokenHash = (%+v, %v), mau permintaan %d", found, err, reset.ID)
+	}
+
+	used, err := r.ResetPassword(ctx, found, "hash-baru", time.Now())
+	if err != nil || !used {
+		t.Fatalf("ResetPassword pertama = (%v, %v), mau (true, nil)", used, err)
+	}
+
+	// Tautan yang sama nggak boleh dipake dua kali.
+	used, err = r.ResetPassword(ctx, found, "hash-lain", time.Now())
+	if err != nil || used {
+		t.Fatalf("ResetPassword kedua = (%v, %v), mau (false, nil)", used, err)
+	}
+
+	updated, err := r.FindByID(ctx, owner.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if updated.PasswordHash != "hash-baru" {
+		t.Errorf("PasswordHash = %q, mau %q", updated.PasswordHash, "hash-baru")
+	}
+}
+
+func TestRepositoryReturnsDatabaseErrors(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	r := NewRepository(db)
+
+	saved := saveTestUser(t, r, "Budi", "budi@example.com")
+
+	// Koneksi yang udah ditutup bikin semua query gagal. Error-nya harus sampai ke pemanggil,
+	// bukan dianggap pengguna nggak ada atau perubahan yang berhasil disimpan.
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("gagal mengambil koneksi database: %v", err)
+	}
+	sqlDB.Close()
+
+	if _, err := r.FindByID(ctx, saved.ID); err == nil {
+		t.Error("FindByID harusnya balikin error dari database")
+	}
+	if _, err := r.FindByEmail(ctx, saved.Email); err == nil {
+		t.Error("FindByEmail harusnya balikin error dari database")
+	}
+	if _, err := r.Update(ctx, saved); err == nil {
+		t.Error("Update harusnya balikin error dari database")
+	}
+}
+
+func TestRepositoryReturnsContextErrors(t *testing.T) {
+	r := NewRepository(newTestDB(t))
+	saved := saveTestUser(t, r, "Budi", "budi@example.com")
+
+	// Request yang dibatalin atau udah lewat deadline harus kebaca sebagai error context-nya,
+	// biar pemanggil bisa bedain sama pengguna yang emang nggak ada.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"dibatalin", canceled, context.Canceled},
+		{"lewat deadline", expired, context.DeadlineExceeded},
+	} {
+		if _, err := r.FindByID(tc.ctx, saved.ID); !errors.Is(err, tc.want) {
+			t.Errorf("FindByID dengan context %s = %v, mau %v", tc.name, err, tc.want)
+		}
+		if _, err := r.FindByEmail(tc.ctx, saved.Email); !errors.Is(err, tc.want) {
+			t.Errorf("FindByEmail dengan context %s = %v, mau %v", tc.name, err, tc.want)
+		}
+		if _, err := r.Update(tc.ctx, saved); !errors.Is(err, tc.want) {
+			t.Errorf("Update dengan context %s = %v, mau %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestServiceMissingUser(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepository(newTestDB(t))
+	s := NewService(r, nil, nil)
+
+	saved := saveTestUser(t, r, "Budi", "budi@example.com")
+
+	// Pengguna yang nggak ada harus jelas kebaca sebagai ErrUserNotFound, bukan pengguna kosong tanpa error.
+	if _, err := s.findUser(ctx, saved.ID+1); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("findUser buat ID yang nggak ada = %v, mau %v", err, ErrUserNotFound)
+	}
+	if _, err := s.GetUserByEmail(ctx, "siti@example.com"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("GetUserByEmail buat email yang nggak ada = %v, mau %v", err, ErrUserNotFound)
+	}
+	if _, err := s.GetUserByID(ctx, saved.ID+1); err == nil {
+		t.Error("GetUserByID buat ID yang nggak ada harusnya balikin error")
+	}
+
+	if found, err := s.findUser(ctx, saved.ID); err != nil || found.ID != saved.ID {
+		t.Errorf("findUser(%d) = (%d, %v), mau pengguna yang disimpan", saved.ID, found.ID, err)
+	}
+}
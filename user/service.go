@@ -2,38 +2,70 @@
 package user
 
 import (
+	"campaignku/mailer"
+	"campaignku/metrics"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"log/slog"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Lama tautan ganti password berlaku.
+const passwordResetTTL = time.Hour
+
 // Service adalah interface yang menentukan operasi-operasi yang dapat dilakukan pada entitas pengguna.
 type Service interface {
-	RegisterUser(input RegisterUserInput) (User, error)
-	Login(input LoginInput) (User, error)
-	IsEmailAvailable(input CheckEmailInput) (bool, error)
-	SaveAvatar(ID int, fileLocation string) (User, error)
-	GetUserByID(ID int) (User, error)
+	RegisterUser(ctx context.Context, input RegisterUserInput) (User, error)
+	Login(ctx context.Context, input LoginInput) (User, error)
+	IsEmailAvailable(ctx context.Context, input CheckEmailInput) (bool, error)
+	SaveAvatar(ctx context.Context, ID int, fileLocation string) (User, error)
+	GetUserByID(ctx context.Context, ID int) (User, error)
+	UpdateProfile(ctx context.Context, input UpdateProfileInput) (User, error)
+	GetAddresses(ctx context.Context, userID int) ([]Address, error)
+	CreateAddress(ctx context.Context, input AddressInput) (Address, error)
+	UpdateAddress(ctx context.Context, inputID GetAddressInput, input AddressInput) (Address, error)
+	DeleteAddress(ctx context.Context, inputID GetAddressInput, user User) error
+	RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error
+	ResetPassword(ctx context.Context, input ResetPasswordInput) error
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	SetRole(ctx context.Context, ID int, role string) (User, error)
+	SetPassword(ctx context.Context, ID int, password string) (User, error)
 }
 
+// Error yang bisa dibalikin sama service pengguna.
+var (
+	ErrAddressNotFound   = errors.New("alamat tidak ditemukan")                                  // Alamat nggak ada atau bukan milik pengguna yang minta.
+	ErrInvalidResetToken = errors.New("tautan ganti password tidak valid atau sudah kadaluarsa") // Token nggak dikenal, udah dipake, atau udah lewat waktunya.
+	ErrUserNotFound      = errors.New("pengguna tidak ditemukan")                                // Nggak ada pengguna dengan email atau ID itu.
+	ErrInvalidRole       = errors.New("role tidak dikenal")                                      // Role-nya bukan salah satu dari Roles.
+)
+
 // service adalah implementasi dari interface Service.
 type service struct {
-	repository Repository
+	repository  Repository
+	mailService mailer.Service   // Buat ngirim email sambutan dan tautan ganti password.
+	recorder    metrics.Recorder // Buat nyatet pendaftaran dan percobaan login.
 }
 
-// NewService digunakan untuk membuat instance baru dari Service dengan repository yang diberikan.
-func NewService(repository Repository) *service {
-	return &service{repository}
+// NewService digunakan untuk membuat instance baru dari Service dengan repository dan service email yang diberikan.
+func NewService(repository Repository, mailService mailer.Service, recorder metrics.Recorder) *service {
+	return &service{repository, mailService, recorder}
 }
 
 // RegisterUser adalah metode untuk mendaftarkan pengguna baru.
 // Metode ini mengambil input dari RegisterUserInput, membuat User baru, dan menyimpannya ke repository.
-func (s *service) RegisterUser(input RegisterUserInput) (User, error) {
+func (s *service) RegisterUser(ctx context.Context, input RegisterUserInput) (User, error) {
 	// Membuat instance User baru
 	user := User{}
 	user.Name = input.Name
 	user.Email = input.Email
 	user.Occupation = input.Occupation
+	user.Locale = input.Locale
 
 	// Menghasilkan hash dari kata sandi menggunakan bcrypt
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.MinCost)
@@ -41,14 +73,19 @@ func (s *service) RegisterUser(input RegisterUserInput) (User, error) {
 		return user, err
 	}
 	user.PasswordHash = string(passwordHash)
-	user.Role = "user"
+	user.Role = RoleUser
 
 	// Menyimpan pengguna baru ke repository
-	newUser, err := s.repository.Save(user)
+	newUser, err := s.repository.Save(ctx, user)
 	if err != nil {
 		return newUser, err
 	}
 
+	s.recorder.UserRegistered()
+
+	// Kirim email sambutan. Emailnya dikirim di belakang, jadi pendaftaran nggak perlu nunggu.
+	s.sendMail(ctx, newUser, mailer.TemplateWelcome, mailer.WelcomeData{Name: newUser.Name})
+
 	// Mengembalikan pengguna baru setelah berhasil mendaftar
 	return newUser, nil
 }
@@ -56,18 +93,19 @@ func (s *service) RegisterUser(input RegisterUserInput) (User, error) {
 // Login adalah metode untuk proses login pengguna.
 // Metode ini mengambil input dari LoginInput, mencari pengguna berdasarkan alamat email,
 // dan memeriksa kesesuaian password menggunakan bcrypt.
-func (s *service) Login(input LoginInput) (User, error) {
+func (s *service) Login(ctx context.Context, input LoginInput) (User, error) {
 	email := input.Email
 	password := input.Password
 
 	// Mencari pengguna berdasarkan alamat email
-	user, err := s.repository.FindByEmail(email)
+	user, err := s.repository.FindByEmail(ctx, email)
 	if err != nil {
 		return User{}, err
 	}
 
 	// Kembalikan error jika tidak ada pengguna dengan alamat email yang diberikan
 	if user.ID == 0 {
+		s.recorder.LoginAttempted(false)
 		return User{}, errors.New("tidak ada pengguna dengan email tersebut")
 	}
 
@@ -75,21 +113,23 @@ func (s *service) Login(input LoginInput) (User, error) {
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 	if err != nil {
 		// Kembalikan error jika password tidak cocok
+		s.recorder.LoginAttempted(false)
 		return User{}, err
 	}
 
 	// Kembalikan pengguna jika login berhasil
+	s.recorder.LoginAttempted(true)
 	return user, nil
 }
 
 // IsEmailAvailable adalah metode untuk memeriksa ketersediaan alamat email.
 // Metode ini mengambil input dari CheckEmailInput, mencari pengguna berdasarkan alamat email,
 // dan mengembalikan informasi ketersediaan alamat email.
-func (s *service) IsEmailAvailable(input CheckEmailInput) (bool, error) {
+func (s *service) IsEmailAvailable(ctx context.Context, input CheckEmailInput) (bool, error) {
 	email := input.Email
 
 	// Mencari pengguna berdasarkan alamat email
-	user, err := s.repository.FindByEmail(email)
+	user, err := s.repository.FindByEmail(ctx, email)
 	if err != nil {
 		return false, err
 	}
@@ -105,15 +145,15 @@ func (s *service) IsEmailAvailable(input CheckEmailInput) (bool, error) {
 // SaveAvatar adalah metode untuk menyimpan lokasi file avatar pengguna.
 // Metode ini mengambil ID pengguna dan lokasi file sebagai parameter, memperbarui lokasi file avatar pengguna,
 // dan menyimpan perubahan ke repository.
-func (s *service) SaveAvatar(ID int, fileLocation string) (User, error) {
-	user, err := s.repository.FindByID(ID)
+func (s *service) SaveAvatar(ctx context.Context, ID int, fileLocation string) (User, error) {
+	user, err := s.repository.FindByID(ctx, ID)
 	if err != nil {
 		return user, err
 	}
 
 	user.AvatarFileName = fileLocation
 
-	updateUser, err := s.repository.Update(user)
+	updateUser, err := s.repository.Update(ctx, user)
 	if err != nil {
 		return updateUser, err
 	}
@@ -124,8 +164,8 @@ func (s *service) SaveAvatar(ID int, fileLocation string) (User, error) {
 // GetUserByID adalah metode untuk mendapatkan pengguna berdasarkan ID pengguna.
 // Metode ini mengambil ID pengguna sebagai parameter, mencari pengguna dengan ID yang sesuai,
 // dan mengembalikan instance User jika ditemukan.
-func (s *service) GetUserByID(ID int) (User, error) {
-	user, err := s.repository.FindByID(ID)
+func (s *service) GetUserByID(ctx context.Context, ID int) (User, error) {
+	user, err := s.repository.FindByID(ctx, ID)
 	if err != nil {
 		return user, err
 	}
@@ -135,3 +175,237 @@ func (s *service) GetUserByID(ID int) (User, error) {
 	}
 	return user, nil
 }
+
+// UpdateProfile adalah metode untuk mengubah nama, pekerjaan, dan bio pengguna yang tampil di profil publik.
+func (s *service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (User, error) {
+	user, err := s.findUser(ctx, input.User.ID)
+	if err != nil {
+		return user, err
+	}
+
+	user.Name = input.Name
+	user.Occupation = input.Occupation
+	user.Bio = input.Bio
+	if input.Locale != "" {
+		user.Locale = input.Locale
+	}
+
+	return s.repository.Update(ctx, user)
+}
+
+// GetAddresses adalah metode untuk mendapatkan semua alamat di buku alamat pengguna.
+func (s *service) GetAddresses(ctx context.Context, userID int) ([]Address, error) {
+	return s.repository.FindAddressesByUserID(ctx, userID)
+}
+
+// CreateAddress adalah metode untuk menambah alamat ke buku alamat pengguna.
+// Alamat pertama otomatis dijadiin alamat utama.
+func (s *service) CreateAddress(ctx context.Context, input AddressInput) (Address, error) {
+	address := Address{}
+	address.UserID = input.User.ID
+	fillAddress(&address, input)
+
+	addresses, err := s.repository.FindAddressesByUserID(ctx, input.User.ID)
+	if err != nil {
+		return address, err
+	}
+	if len(addresses) == 0 {
+		address.IsDefault = true
+	}
+
+	return s.repository.SaveAddress(ctx, address)
+}
+
+// UpdateAddress adalah metode untuk mengubah alamat milik pengguna.
+func (s *service) UpdateAddress(ctx context.Context, inputID GetAddressInput, input AddressInput) (Address, error) {
+	address, err := s.repository.FindAddressByID(ctx, inputID.ID)
+	if err != nil {
+		return address, err
+	}
+	if address.ID == 0 || address.UserID != input.User.ID {
+		return address, ErrAddressNotFound
+	}
+
+	fillAddress(&address, input)
+
+	return s.repository.UpdateAddress(ctx, address)
+}
+
+// DeleteAddress adalah metode untuk menghapus alamat milik pengguna.
+func (s *service) DeleteAddress(ctx context.Context, inputID GetAddressInput, user User) error {
+	address, err := s.repository.FindAddressByID(ctx, inputID.ID)
+	if err != nil {
+		return err
+	}
+	if address.ID == 0 || address.UserID != user.ID {
+		return ErrAddressNotFound
+	}
+
+	return s.repository.DeleteAddress(ctx, address)
+}
+
+// fillAddress nyalin isi input ke alamat. Negara default-nya Indonesia.
+func fillAddress(address *Address, input AddressInput) {
+	address.Label = input.Label
+	address.RecipientName = input.RecipientName
+	address.Phone = input.Phone
+	address.Street = input.Street
+	address.City = input.City
+	address.Province = input.Province
+	address.PostalCode = input.PostalCode
+	address.Country = input.Country
+	address.IsDefault = input.IsDefault
+
+	if address.Country == "" {
+		address.Country = "Indonesia"
+	}
+}
+
+// RequestPasswordReset adalah metode untuk ngirim tautan ganti password ke email pengguna.
+// Kalo email-nya nggak terdaftar tetep dianggap berhasil, biar nggak bisa dipake buat nebak email siapa aja yang terdaftar.
+func (s *service) RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) error {
+	user, err := s.repository.FindByEmail(ctx, input.Email)
+	if err != nil {
+		return err
+	}
+	if user.ID == 0 {
+		return nil
+	}
+
+	token, err := resetToken()
+	if err != nil {
+		return err
+	}
+
+	reset := PasswordReset{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	_, err = s.repository.SavePasswordReset(ctx, reset)
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(ctx, user, mailer.TemplatePasswordReset, mailer.PasswordResetData{
+		Name:             user.Name,
+		ResetPath:        "/reset-password?token=" + token,
+		ExpiresInMinutes: int(passwordResetTTL / time.Minute),
+	})
+	return nil
+}
+
+// ResetPassword adalah metode untuk ganti password pake token dari email. Tiap token cuma bisa dipake sekali.
+func (s *service) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
+	reset, err := s.repository.FindPasswordResetByTokenHash(ctx, hashResetToken(input.Token))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if reset.ID == 0 || reset.UsedAt != nil || now.After(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.MinCost)
+	if err != nil {
+		return err
+	}
+
+	used, err := s.repository.ResetPassword(ctx, reset, string(passwordHash), now)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidResetToken
+	}
+	return nil
+}
+
+// sendMail masukin email buat pengguna ke antrean. Kalo gagal cuma dicatat aja.
+func (s *service) sendMail(ctx context.Context, user User, template string, data interface{}) {
+	to := mailer.Recipient{Email: user.Email, Name: user.Name, Locale: user.Locale}
+	if err := s.mailService.Send(ctx, to, template, data); err != nil {
+		slog.ErrorContext(ctx, "gagal mengirim email", "template", template, "user_id", user.ID, "error", err)
+	}
+}
+
+// resetToken bikin token acak buat tautan ganti password.
+func resetToken() (string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
+
+// hashResetToken ngitung hash token ganti password buat disimpen dan dicari di database.
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetUserByEmail adalah metode untuk mendapatkan pengguna berdasarkan alamat email.
+func (s *service) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	user, err := s.repository.FindByEmail(ctx, email)
+	if err != nil {
+		return user, err
+	}
+	if user.ID == 0 {
+		return user, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// SetRole adalah metode untuk mengganti role pengguna, misal buat ngangkat pengguna jadi admin.
+func (s *service) SetRole(ctx context.Context, ID int, role string) (User, error) {
+	if !isKnownRole(role) {
+		return User{}, ErrInvalidRole
+	}
+
+	user, err := s.findUser(ctx, ID)
+	if err != nil {
+		return user, err
+	}
+
+	user.Role = role
+	return s.repository.Update(ctx, user)
+}
+
+// SetPassword adalah metode untuk mengganti password pengguna langsung tanpa token, cuma dipake dari perintah admin.
+func (s *service) SetPassword(ctx context.Context, ID int, password string) (User, error) {
+	user, err := s.findUser(ctx, ID)
+	if err != nil {
+		return user, err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		return user, err
+	}
+
+	user.PasswordHash = string(passwordHash)
+	return s.repository.Update(ctx, user)
+}
+
+// findUser ngambil pengguna berdasarkan ID dan balikin ErrUserNotFound kalo nggak ada.
+func (s *service) findUser(ctx context.Context, ID int) (User, error) {
+	user, err := s.repository.FindByID(ctx, ID)
+	if err != nil {
+		return user, err
+	}
+	if user.ID == 0 {
+		return user, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// isKnownRole ngecek role-nya ada di daftar Roles apa enggak.
+func isKnownRole(role string) bool {
+	for _, known := range Roles {
+		if role == known {
+			return true
+		}
+	}
+	return false
+}
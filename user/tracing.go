@@ -0,0 +1,136 @@
+package user
+
+import (
+	"campaignku/tracing"
+	"context"
+)
+
+// tracedService ngebungkus Service biar tiap pemanggilannya jadi satu span, lengkap sama error-nya.
+type tracedService struct {
+	next Service
+}
+
+// NewTracedService bungkus service user biar tiap pemanggilannya kecatat di trace.
+func NewTracedService(next Service) *tracedService {
+	return &tracedService{next}
+}
+
+// RegisterUser nge-trace RegisterUser dari service aslinya.
+func (s *tracedService) RegisterUser(ctx context.Context, input RegisterUserInput) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.RegisterUser")
+	defer func() { end(err) }()
+
+	return s.next.RegisterUser(ctx, input)
+}
+
+// Login nge-trace Login dari service aslinya.
+func (s *tracedService) Login(ctx context.Context, input LoginInput) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.Login")
+	defer func() { end(err) }()
+
+	return s.next.Login(ctx, input)
+}
+
+// IsEmailAvailable nge-trace IsEmailAvailable dari service aslinya.
+func (s *tracedService) IsEmailAvailable(ctx context.Context, input CheckEmailInput) (result bool, err error) {
+	ctx, end := tracing.Start(ctx, "user.IsEmailAvailable")
+	defer func() { end(err) }()
+
+	return s.next.IsEmailAvailable(ctx, input)
+}
+
+// SaveAvatar nge-trace SaveAvatar dari service aslinya.
+func (s *tracedService) SaveAvatar(ctx context.Context, ID int, fileLocation string) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.SaveAvatar")
+	defer func() { end(err) }()
+
+	return s.next.SaveAvatar(ctx, ID, fileLocation)
+}
+
+// GetUserByID nge-trace GetUserByID dari service aslinya.
+func (s *tracedService) GetUserByID(ctx context.Context, ID int) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.GetUserByID")
+	defer func() { end(err) }()
+
+	return s.next.GetUserByID(ctx, ID)
+}
+
+// UpdateProfile nge-trace UpdateProfile dari service aslinya.
+func (s *tracedService) UpdateProfile(ctx context.Context, input UpdateProfileInput) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.UpdateProfile")
+	defer func() { end(err) }()
+
+	return s.next.UpdateProfile(ctx, input)
+}
+
+// GetAddresses nge-trace GetAddresses dari service aslinya.
+func (s *tracedService) GetAddresses(ctx context.Context, userID int) (result []Address, err error) {
+	ctx, end := tracing.Start(ctx, "user.GetAddresses")
+	defer func() { end(err) }()
+
+	return s.next.GetAddresses(ctx, userID)
+}
+
+// CreateAddress nge-trace CreateAddress dari service aslinya.
+func (s *tracedService) CreateAddress(ctx context.Context, input AddressInput) (result Address, err error) {
+	ctx, end := tracing.Start(ctx, "user.CreateAddress")
+	defer func() { end(err) }()
+
+	return s.next.CreateAddress(ctx, input)
+}
+
+// UpdateAddress nge-trace UpdateAddress dari service aslinya.
+func (s *tracedService) UpdateAddress(ctx context.Context, inputID GetAddressInput, input AddressInput) (result Address, err error) {
+	ctx, end := tracing.Start(ctx, "user.UpdateAddress")
+	defer func() { end(err) }()
+
+	return s.next.UpdateAddress(ctx, inputID, input)
+}
+
+// DeleteAddress nge-trace DeleteAddress dari service aslinya.
+func (s *tracedService) DeleteAddress(ctx context.Context, inputID GetAddressInput, user User) (err error) {
+	ctx, end := tracing.Start(ctx, "user.DeleteAddress")
+	defer func() { end(err) }()
+
+	return s.next.DeleteAddress(ctx, inputID, user)
+}
+
+// RequestPasswordReset nge-trace RequestPasswordReset dari service aslinya.
+func (s *tracedService) RequestPasswordReset(ctx context.Context, input RequestPasswordResetInput) (err error) {
+	ctx, end := tracing.Start(ctx, "user.RequestPasswordReset")
+	defer func() { end(err) }()
+
+	return s.next.RequestPasswordReset(ctx, input)
+}
+
+// ResetPassword nge-trace ResetPassword dari service aslinya.
+func (s *tracedService) ResetPassword(ctx context.Context, input ResetPasswordInput) (err error) {
+	ctx, end := tracing.Start(ctx, "user.ResetPassword")
+	defer func() { end(err) }()
+
+	return s.next.ResetPassword(ctx, input)
+}
+
+// GetUserByEmail nge-trace GetUserByEmail dari service aslinya.
+func (s *tracedService) GetUserByEmail(ctx context.Context, email string) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.GetUserByEmail")
+	defer func() { end(err) }()
+
+	return s.next.GetUserByEmail(ctx, email)
+}
+
+// SetRole nge-trace SetRole dari service aslinya.
+func (s *tracedService) SetRole(ctx context.Context, ID int, role string) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.SetRole")
+	defer func() { end(err) }()
+
+	return s.next.SetRole(ctx, ID, role)
+}
+
+// SetPassword nge-trace SetPassword dari service aslinya.
+func (s *tracedService) SetPassword(ctx context.Context, ID int, password string) (result User, err error) {
+	ctx, end := tracing.Start(ctx, "user.SetPassword")
+	defer func() { end(err) }()
+
+	return s.next.SetPassword(ctx, ID, password)
+}
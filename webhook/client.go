@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrUnsafeURL dibalikin kalo URL webhook bukan http(s) atau host-nya ngarah ke alamat internal.
+// Tanpa pengecekan ini, user bisa nyuruh server nembak layanan internal atau alamat metadata cloud.
+var ErrUnsafeURL = errors.New("URL webhook harus http atau https dan mengarah ke alamat publik")
+
+// blockedNetworks adalah rentang alamat khusus yang nggak boleh dituju webhook, selain yang udah dicek sama method net.IP.
+var blockedNetworks = parseNetworks(
+	"0.0.0.0/8",     // "Jaringan ini", di Linux nyambungnya ke localhost.
+	"100.64.0.0/10", // Shared address space, dipake buat CGNAT.
+	"192.0.0.0/24",  // Alokasi protokol IETF.
+	"198.18.0.0/15", // Jaringan buat benchmarking.
+	"64:ff9b::/96",  // NAT64, bisa dipake buat nyamarin alamat IPv4 internal.
+)
+
+// NewClient bikin HTTP client buat ngirim webhook. Alamat tujuannya dicek lagi tepat sebelum nyambung,
+// jadi host yang DNS-nya diganti ke alamat internal setelah lolos CheckURL (DNS rebinding) tetep ketahan.
+// Proxy dari environment nggak dipake biar pengecekannya nggak kelewat, dan redirect nggak diikutin
+// biar penerima nggak bisa ngalihin request-nya ke alamat lain.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   checkDialAddress,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// CheckURL mastiin URL webhook pake http atau https dan semua alamat hasil resolve host-nya alamat publik.
+// Host yang nggak bisa di-resolve juga ditolak.
+func CheckURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrUnsafeURL
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil || len(addresses) == 0 {
+		return ErrUnsafeURL
+	}
+
+	for _, address := range addresses {
+		if !isPublicIP(address.IP) {
+			return ErrUnsafeURL
+		}
+	}
+	return nil
+}
+
+// checkDialAddress dipanggil sama dialer buat tiap alamat yang mau disambungin, setelah host-nya di-resolve.
+func checkDialAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return ErrUnsafeURL
+	}
+	return nil
+}
+
+// isPublicIP ngecek alamat IP boleh dituju webhook: bukan loopback, jaringan privat, unique-local,
+// link-local (termasuk alamat metadata cloud 169.254.169.254), multicast, atau rentang khusus lainnya.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNetworks ngubah daftar CIDR jadi *net.IPNet. Dipanggil sekali pas package dimuat, jadi CIDR yang salah langsung panic.
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
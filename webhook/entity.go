@@ -0,0 +1,67 @@
+// Package webhook ngirim event ke sistem milik kreator dan partner lewat HTTP, ditandatangani pake HMAC.
+package webhook
+
+import (
+	"strings"
+	"time"
+)
+
+// EventTest adalah jenis event buat ngetes langganan webhook dari dashboard.
+const EventTest = "webhook.test"
+
+// Status pengiriman webhook.
+const (
+	DeliveryPending   = "pending"   // Belum berhasil, dicoba lagi sesuai jadwal.
+	DeliverySucceeded = "succeeded" // Penerima balas dengan status 2xx.
+	DeliveryFailed    = "failed"    // Udah nyerah setelah beberapa kali gagal.
+)
+
+// Subscription adalah langganan webhook milik seorang user.
+// Events disimpen sebagai daftar jenis event yang dipisah koma, misal "transaction.paid,campaign.funded".
+type Subscription struct {
+	ID        int
+	UserID    int
+	URL       string
+	Secret    string
+	Events    string
+	IsActive  bool
+	CreatedAt time.Time
+	UpdateAt  time.Time `gorm:"column:updated_at"`
+}
+
+// EventTypes ngebalikin daftar jenis event yang dilanggan.
+func (s Subscription) EventTypes() []string {
+	if s.Events == "" {
+		return []string{}
+	}
+	return strings.Split(s.Events, ",")
+}
+
+// Subscribes ngecek langganan ini nerima jenis event tertentu apa enggak.
+func (s Subscription) Subscribes(eventType string) bool {
+	for _, subscribed := range s.EventTypes() {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery adalah satu pengiriman event ke satu langganan, sekaligus jadi log-nya.
+// Body disimpen utuh biar tiap percobaan ulang ngirim isi yang persis sama.
+// Dari balasan penerima cuma status HTTP-nya yang disimpen, isinya nggak.
+type Delivery struct {
+	ID             int
+	SubscriptionID int
+	EventID        string
+	EventType      string
+	Body           string
+	Status         string
+	Attempts       int
+	NextAttemptAt  *time.Time
+	ResponseStatus int
+	Error          string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
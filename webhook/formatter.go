@@ -0,0 +1,84 @@
+package webhook
+
+import "time"
+
+// SubscriptionFormatter adalah struktur data buat satu langganan webhook.
+// Secret cuma ditampilin sekali, waktu langganannya baru dibikin.
+type SubscriptionFormatter struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"is_active"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FormatSubscription mengonversi data langganan menjadi SubscriptionFormatter tanpa secret.
+func FormatSubscription(subscription Subscription) SubscriptionFormatter {
+	return SubscriptionFormatter{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    subscription.EventTypes(),
+		IsActive:  subscription.IsActive,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
+
+// FormatNewSubscription mengonversi langganan yang baru dibikin menjadi SubscriptionFormatter, lengkap sama secret-nya.
+func FormatNewSubscription(subscription Subscription) SubscriptionFormatter {
+	formatter := FormatSubscription(subscription)
+	formatter.Secret = subscription.Secret
+	return formatter
+}
+
+// FormatSubscriptions mengonversi daftar langganan menjadi daftar SubscriptionFormatter.
+func FormatSubscriptions(subscriptions []Subscription) []SubscriptionFormatter {
+	subscriptionsFormatter := []SubscriptionFormatter{}
+
+	for _, subscription := range subscriptions {
+		subscriptionsFormatter = append(subscriptionsFormatter, FormatSubscription(subscription))
+	}
+
+	return subscriptionsFormatter
+}
+
+// DeliveryFormatter adalah struktur data buat satu baris log pengiriman webhook.
+type DeliveryFormatter struct {
+	ID             int        `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	ResponseStatus int        `json:"response_status"`
+	Error          string     `json:"error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// FormatDelivery mengonversi data pengiriman menjadi DeliveryFormatter.
+func FormatDelivery(delivery Delivery) DeliveryFormatter {
+	return DeliveryFormatter{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+// FormatDeliveries mengonversi daftar pengiriman menjadi daftar DeliveryFormatter.
+func FormatDeliveries(deliveries []Delivery) []DeliveryFormatter {
+	deliveriesFormatter := []DeliveryFormatter{}
+
+	for _, delivery := range deliveries {
+		deliveriesFormatter = append(deliveriesFormatter, FormatDelivery(delivery))
+	}
+
+	return deliveriesFormatter
+}
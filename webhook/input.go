@@ -0,0 +1,16 @@
+package webhook
+
+import "campaignku/user"
+
+// GetSubscriptionInput adalah struktur data buat nampung ID langganan webhook dari URI.
+type GetSubscriptionInput struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+// SubscriptionInput adalah struktur data yang digunakan sebagai input saat bikin atau ngubah langganan webhook.
+type SubscriptionInput struct {
+	URL      string    `json:"url" binding:"required,url,max=500"`
+	Events   []string  `json:"events" binding:"required,min=1,dive,oneof=transaction.paid campaign.funded campaign.closed"`
+	IsActive *bool     `json:"is_active"` // Kosong artinya aktif.
+	User     user.User // Diisi dari user yang lagi login, bukan dari JSON.
+}
@@ -0,0 +1,137 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repository adalah interface untuk fungsi-fungsi database yang berkaitan dengan webhook.
+type Repository interface {
+	FindSubscriptionsByUserID(ctx context.Context, userID int) ([]Subscription, error)                     // Fungsi untuk dapetin semua langganan milik user.
+	FindSubscriptionByID(ctx context.Context, ID int) (Subscription, error)                                // Fungsi untuk dapetin satu langganan berdasarkan ID.
+	SaveSubscription(ctx context.Context, subscription Subscription) (Subscription, error)                 // Fungsi untuk nyimpen langganan baru.
+	UpdateSubscription(ctx context.Context, subscription Subscription) (Subscription, error)               // Fungsi untuk nyimpen perubahan langganan.
+	DeleteSubscription(ctx context.Context, subscription Subscription) error                               // Fungsi untuk ngapus langganan beserta log pengirimannya.
+	SaveDeliveries(ctx context.Context, deliveries []Delivery) ([]Delivery, error)                         // Fungsi untuk nyimpen pengiriman baru.
+	UpdateDelivery(ctx context.Context, delivery Delivery) (Delivery, error)                               // Fungsi untuk nyimpen hasil percobaan pengiriman.
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error)                   // Fungsi untuk dapetin pengiriman yang udah waktunya dicoba.
+	FindDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int, limit int) ([]Delivery, error) // Fungsi untuk dapetin log pengiriman terbaru sebuah langganan.
+}
+
+// repository adalah implementasi dari Repository, pakai GORM.
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository adalah fungsi pembuat repository baru.
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db}
+}
+
+// FindSubscriptionsByUserID adalah method dari repository untuk dapetin semua langganan milik user.
+func (r *repository) FindSubscriptionsByUserID(ctx context.Context, userID int) ([]Subscription, error) {
+	var subscriptions []Subscription
+
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&subscriptions).Error
+	if err != nil {
+		return subscriptions, err
+	}
+	return subscriptions, nil
+}
+
+// FindSubscriptionByID adalah method dari repository untuk dapetin satu langganan berdasarkan ID.
+func (r *repository) FindSubscriptionByID(ctx context.Context, ID int) (Subscription, error) {
+	var subscription Subscription
+
+	err := r.db.WithContext(ctx).Where("id = ?", ID).Find(&subscription).Error
+	if err != nil {
+		return subscription, err
+	}
+	return subscription, nil
+}
+
+// SaveSubscription adalah method dari repository untuk nyimpen langganan baru.
+func (r *repository) SaveSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	now := time.Now()
+	subscription.CreatedAt = now
+	subscription.UpdateAt = now
+
+	err := r.db.WithContext(ctx).Create(&subscription).Error
+	if err != nil {
+		return subscription, err
+	}
+	return subscription, nil
+}
+
+// UpdateSubscription adalah method dari repository untuk nyimpen perubahan langganan.
+func (r *repository) UpdateSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	subscription.UpdateAt = time.Now()
+
+	err := r.db.WithContext(ctx).Save(&subscription).Error
+	if err != nil {
+		return subscription, err
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription adalah method dari repository untuk ngapus langganan beserta log pengirimannya.
+func (r *repository) DeleteSubscription(ctx context.Context, subscription Subscription) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", subscription.ID).Delete(&Delivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&subscription).Error
+	})
+}
+
+// SaveDeliveries adalah method dari repository untuk nyimpen pengiriman baru.
+func (r *repository) SaveDeliveries(ctx context.Context, deliveries []Delivery) ([]Delivery, error) {
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	err := r.db.WithContext(ctx).Create(&deliveries).Error
+	if err != nil {
+		return deliveries, err
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery adalah method dari repository untuk nyimpen hasil percobaan pengiriman.
+func (r *repository) UpdateDelivery(ctx context.Context, delivery Delivery) (Delivery, error) {
+	err := r.db.WithContext(ctx).Save(&delivery).Error
+	if err != nil {
+		return delivery, err
+	}
+	return delivery, nil
+}
+
+// FindDueDeliveries adalah method dari repository untuk dapetin pengiriman yang masih pending dan udah waktunya dicoba.
+func (r *repository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error) {
+	var deliveries []Delivery
+
+	err := r.db.WithContext(ctx).Where("status = ? AND next_attempt_at <= ?", DeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return deliveries, err
+	}
+	return deliveries, nil
+}
+
+// FindDeliveriesBySubscriptionID adalah method dari repository untuk dapetin log pengiriman terbaru sebuah langganan.
+func (r *repository) FindDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int, limit int) ([]Delivery, error) {
+	var deliveries []Delivery
+
+	err := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return deliveries, err
+	}
+	return deliveries, nil
+}
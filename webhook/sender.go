@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header yang dikirim di tiap webhook.
+const (
+	HeaderEvent     = "X-Campaignku-Event"
+	HeaderDelivery  = "X-Campaignku-Delivery"
+	HeaderSignature = "X-Campaignku-Signature"
+)
+
+// Batas panjang pesan error yang disimpen di log pengiriman.
+const errorLimit = 255
+
+// Sign bikin tanda tangan webhook: HMAC-SHA256 dari "<timestamp>.<body>" pake secret langganan, dalam bentuk hex.
+// Penerima ngitung ulang tanda tangan ini dan nolak pesan yang timestamp-nya udah terlalu lama biar nggak bisa diputar ulang.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// send ngirim satu pengiriman ke URL langganan dan balikin status HTTP balasannya.
+// Isi balasan penerima sengaja nggak dibaca, biar webhook nggak bisa dipake buat ngintip isi layanan lain.
+// Error dibalikin kalo request-nya gagal atau penerima balas selain 2xx.
+func send(ctx context.Context, client *http.Client, subscription Subscription, delivery Delivery, now time.Time) (int, error) {
+	body := []byte(delivery.Body)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := now.Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Campaignku-Webhook/1.0")
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderDelivery, delivery.EventID)
+	request.Header.Set(HeaderSignature, fmt.Sprintf("t=%d,v1=%s", timestamp, Sign(subscription.Secret, timestamp, body)))
+
+	response, err := client.Do(request)
+	if err != nil {
+		// Alamat yang ditolak nggak ikut ditampilin, biar hasil resolve host internal nggak bocor.
+		if errors.Is(err, ErrUnsafeURL) {
+			return 0, ErrUnsafeURL
+		}
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("penerima membalas dengan status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// truncateError motong pesan error biar nggak lebih dari errorLimit byte, tanpa motong di tengah karakter.
+func truncateError(err error) string {
+	message := err.Error()
+	if len(message) <= errorLimit {
+		return message
+	}
+	return strings.ToValidUTF8(message[:errorLimit], "")
+}
@@ -0,0 +1,333 @@
+package webhook
+
+import (
+	"campaignku/campaign"
+	"campaignku/pubsub"
+	"campaignku/transaction"
+	"campaignku/user"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Pengaturan percobaan ulang pengiriman. Jeda antar percobaan dobel tiap kali gagal, mulai dari deliveryBackoff.
+const (
+	maxDeliveryAttempts = 10
+	deliveryBackoff     = 30 * time.Second
+	maxDeliveryBackoff  = 6 * time.Hour
+	deliveryBatchSize   = 50
+	deliveryLogLimit    = 50
+)
+
+// SupportedEvents adalah jenis event yang bisa dilanggan lewat webhook. Tiap jenis sama dengan nama topik pubsub-nya.
+var SupportedEvents = []string{
+	transaction.TopicTransactionPaid,
+	campaign.TopicCampaignFunded,
+	campaign.TopicCampaignClosed,
+}
+
+// ErrSubscriptionNotFound dibalikin kalo langganan nggak ada atau bukan milik user yang minta.
+var ErrSubscriptionNotFound = errors.New("langganan webhook tidak ditemukan")
+
+// Service adalah interface yang mendefinisikan fungsi yang harus ada di service webhook.
+type Service interface {
+	GetSubscriptions(ctx context.Context, userID int) ([]Subscription, error)                                            // Fungsi buat dapetin semua langganan milik user.
+	CreateSubscription(ctx context.Context, input SubscriptionInput) (Subscription, error)                               // Fungsi buat bikin langganan baru, secret-nya dibikinin otomatis.
+	UpdateSubscription(ctx context.Context, inputID GetSubscriptionInput, input SubscriptionInput) (Subscription, error) // Fungsi buat ngubah langganan.
+	DeleteSubscription(ctx context.Context, inputID GetSubscriptionInput, user user.User) error                          // Fungsi buat ngapus langganan.
+	GetDeliveries(ctx context.Context, inputID GetSubscriptionInput, user user.User) ([]Delivery, error)                 // Fungsi buat dapetin log pengiriman terbaru sebuah langganan.
+	SendTestEvent(ctx context.Context, inputID GetSubscriptionInput, user user.User) (Delivery, error)                   // Fungsi buat ngirim event percobaan langsung ke sebuah langganan.
+	HandleEvent(ctx context.Context, event pubsub.Event) error                                                           // Fungsi buat nyiapin pengiriman dari event pubsub.
+	DeliverPending(ctx context.Context, now time.Time) error                                                             // Fungsi buat ngirim (ulang) pengiriman yang udah waktunya.
+}
+
+// service adalah struct yang implementasi dari Service.
+type service struct {
+	repository Repository
+	client     *http.Client
+}
+
+// NewService adalah fungsi pembuat service baru.
+func NewService(repository Repository, client *http.Client) *service {
+	return &service{repository, client}
+}
+
+// GetSubscriptions adalah method dari service buat dapetin semua langganan milik user.
+func (s *service) GetSubscriptions(ctx context.Context, userID int) ([]Subscription, error) {
+	return s.repository.FindSubscriptionsByUserID(ctx, userID)
+}
+
+// CreateSubscription adalah method dari service buat bikin langganan webhook baru.
+// URL-nya harus ngarah ke alamat publik.
+func (s *service) CreateSubscription(ctx context.Context, input SubscriptionInput) (Subscription, error) {
+	if err := CheckURL(ctx, input.URL); err != nil {
+		return Subscription{}, err
+	}
+
+	secret, err := randomToken("whsec_", 24)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	subscription := Subscription{
+		UserID:   input.User.ID,
+		URL:      input.URL,
+		Secret:   secret,
+		Events:   strings.Join(uniqueEvents(input.Events), ","),
+		IsActive: input.IsActive == nil || *input.IsActive,
+	}
+
+	return s.repository.SaveSubscription(ctx, subscription)
+}
+
+// UpdateSubscription adalah method dari service buat ngubah URL, jenis event, atau status aktif langganan.
+// URL barunya harus ngarah ke alamat publik.
+func (s *service) UpdateSubscription(ctx context.Context, inputID GetSubscriptionInput, input SubscriptionInput) (Subscription, error) {
+	subscription, err := s.findOwnedSubscription(ctx, inputID, input.User)
+	if err != nil {
+		return subscription, err
+	}
+	if err := CheckURL(ctx, input.URL); err != nil {
+		return subscription, err
+	}
+
+	subscription.URL = input.URL
+	subscription.Events = strings.Join(uniqueEvents(input.Events), ",")
+	if input.IsActive != nil {
+		subscription.IsActive = *input.IsActive
+	}
+
+	return s.repository.UpdateSubscription(ctx, subscription)
+}
+
+// DeleteSubscription adalah method dari service buat ngapus langganan.
+func (s *service) DeleteSubscription(ctx context.Context, inputID GetSubscriptionInput, user user.User) error {
+	subscription, err := s.findOwnedSubscription(ctx, inputID, user)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteSubscription(ctx, subscription)
+}
+
+// GetDeliveries adalah method dari service buat dapetin log pengiriman terbaru sebuah langganan.
+func (s *service) GetDeliveries(ctx context.Context, inputID GetSubscriptionInput, user user.User) ([]Delivery, error) {
+	subscription, err := s.findOwnedSubscription(ctx, inputID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.FindDeliveriesBySubscriptionID(ctx, subscription.ID, deliveryLogLimit)
+}
+
+// SendTestEvent adalah method dari service buat ngirim event percobaan ke sebuah langganan.
+// Pengirimannya dicoba langsung, kalo gagal tetep dicoba ulang kayak pengiriman biasa.
+func (s *service) SendTestEvent(ctx context.Context, inputID GetSubscriptionInput, user user.User) (Delivery, error) {
+	subscription, err := s.findOwnedSubscription(ctx, inputID, user)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	now := time.Now()
+	data, err := json.Marshal(map[string]string{"message": "Ini event percobaan dari Campaignku"})
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	deliveries, err := s.newDeliveries([]Subscription{subscription}, EventTest, data, now)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	deliveries, err = s.repository.SaveDeliveries(ctx, deliveries)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return s.attempt(ctx, deliveries[0], subscription, now)
+}
+
+// HandleEvent adalah method dari service buat nyiapin pengiriman ke semua langganan aktif milik pemilik event.
+// Pengirimannya sendiri dilakuin belakangan sama DeliverPending, jadi pengirim event nggak ikut ketahan.
+func (s *service) HandleEvent(ctx context.Context, event pubsub.Event) error {
+	if event.UserID == 0 {
+		return nil
+	}
+
+	subscriptions, err := s.repository.FindSubscriptionsByUserID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	var targets []Subscription
+	for _, subscription := range subscriptions {
+		if subscription.IsActive && subscription.Subscribes(event.Type) {
+			targets = append(targets, subscription)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	deliveries, err := s.newDeliveries(targets, event.Type, event.Payload, time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repository.SaveDeliveries(ctx, deliveries)
+	return err
+}
+
+// DeliverPending adalah method dari service buat ngirim pengiriman yang masih pending dan udah waktunya dicoba.
+func (s *service) DeliverPending(ctx context.Context, now time.Time) error {
+	deliveries, err := s.repository.FindDueDeliveries(ctx, now, deliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make(map[int]Subscription)
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.repository.FindSubscriptionByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				return err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		if _, err := s.attempt(ctx, delivery, subscription, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attempt nyoba ngirim satu pengiriman dan nyimpen hasilnya ke log.
+// Error dari penerima nggak dibalikin, cukup dicatat di pengirimannya biar dicoba lagi.
+func (s *service) attempt(ctx context.Context, delivery Delivery, subscription Subscription, now time.Time) (Delivery, error) {
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+
+	// Langganan yang udah dihapus atau dimatiin nggak dikirimin lagi.
+	if subscription.ID == 0 || !subscription.IsActive {
+		delivery.Status = DeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = "langganan webhook sudah dihapus atau tidak aktif"
+		return s.repository.UpdateDelivery(ctx, delivery)
+	}
+
+	status, err := send(ctx, s.client, subscription, delivery, now)
+	delivery.ResponseStatus = status
+
+	if err != nil {
+		delivery.Error = truncateError(err)
+		if delivery.Attempts >= maxDeliveryAttempts {
+			delivery.Status = DeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			nextAttemptAt := now.Add(deliveryDelay(delivery.Attempts))
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+		return s.repository.UpdateDelivery(ctx, delivery)
+	}
+
+	deliveredAt := now
+	delivery.Status = DeliverySucceeded
+	delivery.Error = ""
+	delivery.NextAttemptAt = nil
+	delivery.DeliveredAt = &deliveredAt
+	return s.repository.UpdateDelivery(ctx, delivery)
+}
+
+// newDeliveries nyusun body webhook sekali, terus bikin satu pengiriman buat tiap langganan.
+// Semua pengiriman dari event yang sama punya EventID yang sama, biar penerima bisa ngenalin event dobel.
+func (s *service) newDeliveries(subscriptions []Subscription, eventType string, data []byte, now time.Time) ([]Delivery, error) {
+	eventID, err := randomToken("evt_", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(envelope{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: now,
+		Data:      json.RawMessage(data),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]Delivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		nextAttemptAt := now
+		deliveries = append(deliveries, Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Body:           string(body),
+			Status:         DeliveryPending,
+			NextAttemptAt:  &nextAttemptAt,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	return deliveries, nil
+}
+
+// findOwnedSubscription ngambil langganan dan mastiin langganannya milik user itu.
+func (s *service) findOwnedSubscription(ctx context.Context, inputID GetSubscriptionInput, user user.User) (Subscription, error) {
+	subscription, err := s.repository.FindSubscriptionByID(ctx, inputID.ID)
+	if err != nil {
+		return subscription, err
+	}
+	if subscription.ID == 0 || subscription.UserID != user.ID {
+		return subscription, ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// envelope adalah bentuk body JSON yang dikirim ke penerima webhook.
+type envelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// deliveryDelay ngitung jeda sebelum percobaan pengiriman berikutnya.
+func deliveryDelay(attempts int) time.Duration {
+	delay := deliveryBackoff << (attempts - 1)
+	if delay > maxDeliveryBackoff || delay <= 0 {
+		return maxDeliveryBackoff
+	}
+	return delay
+}
+
+// uniqueEvents ngebuang jenis event yang dobel tanpa ngubah urutannya.
+func uniqueEvents(events []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			unique = append(unique, event)
+		}
+	}
+	return unique
+}
+
+// randomToken bikin string acak dengan awalan tertentu, misal buat secret langganan dan ID event.
+func randomToken(prefix string, size int) (string, error) {
+	buffer := make([]byte, size)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(buffer), nil
+}